@@ -0,0 +1,256 @@
+// Command fakeinfo is a local stand-in for the people info API, answering
+// GET /info?passportSerie=&passportNumber= the way internal/clients/swagger expects
+// from a fixture file, with optional latency and injected failures:
+//
+//	go run ./cmd/fakeinfo -addr :8081 -file cmd/fakeinfo/people.json -latency 100ms -error-rate 0.1
+//
+// Point API_URL at it, e.g. API_URL=http://localhost:8081.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/clients"
+	"github.com/gogoalish/timetracker/internal/clients/swagger"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type passport struct {
+	serie  int32
+	number int32
+}
+
+type fakeServer struct {
+	people         service.ApiClient
+	latency        time.Duration
+	jitter         time.Duration
+	errorRate      float64
+	badRequestRate float64
+	notFound       bool
+	inject         map[passport]int
+	l              *zap.Logger
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	file := flag.String("file", "", "fixture file, .json or .csv; the built-in fixtures if empty")
+	latency := flag.Duration("latency", 0, "delay before every response")
+	jitter := flag.Duration("jitter", 0, "random extra delay, up to this much")
+	errorRate := flag.Float64("error-rate", 0, "share of requests answered with 500, from 0 to 1")
+	badRequestRate := flag.Float64("bad-request-rate", 0, "share of requests answered with 400, from 0 to 1")
+	notFound := flag.Bool("not-found", false, "answer unknown passports with 404, which the API contract doesn't define, instead of 400")
+	inject := flag.String("inject", "", "fixed answers for passports, e.g. 1234:567890=500,4321:100200=400")
+	seed := flag.Int64("seed", 1, "seed of the latency and error randomness, for reproducible runs")
+	flag.Parse()
+
+	l, err := logger.New()
+	if err != nil {
+		log.Fatal("error init logger", err)
+	}
+	defer l.Sync()
+
+	s, err := newFakeServer(*file, *inject, *seed)
+	if err != nil {
+		l.Fatal(fmt.Sprint("error init fakeinfo: ", err))
+	}
+	if *errorRate < 0 || *badRequestRate < 0 || *errorRate+*badRequestRate > 1 {
+		l.Fatal("error init fakeinfo: -error-rate and -bad-request-rate must be non-negative and add up to at most 1")
+	}
+	s.latency = *latency
+	s.jitter = *jitter
+	s.errorRate = *errorRate
+	s.badRequestRate = *badRequestRate
+	s.notFound = *notFound
+	s.l = l
+
+	httpServer := &http.Server{
+		Addr:    *addr,
+		Handler: s.handler(),
+	}
+	notify := make(chan error, 1)
+	go func() {
+		notify <- httpServer.ListenAndServe()
+	}()
+	l.Info(fmt.Sprintf("fakeinfo is listening on: %s", *addr))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case sig := <-interrupt:
+		l.Info("fakeinfo - signal:" + sig.String())
+	case err = <-notify:
+		l.Error(fmt.Sprint("fakeinfo - httpServer.ListenAndServe: ", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	err = httpServer.Shutdown(ctx)
+	if err != nil {
+		l.Error(fmt.Sprint("fakeinfo - httpServer.Shutdown: ", err))
+	}
+}
+
+func newFakeServer(file, inject string, seed int64) (*fakeServer, error) {
+	s := &fakeServer{
+		people: clients.NewStaticProvider(clients.Fixtures...),
+		inject: make(map[passport]int),
+		l:      zap.NewNop(),
+		rnd:    rand.New(rand.NewSource(seed)),
+	}
+	if file != "" {
+		people, err := clients.NewFileProvider(file)
+		if err != nil {
+			return nil, err
+		}
+		s.people = people
+	}
+
+	for _, item := range strings.Split(inject, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		p, status, err := parseInjection(item)
+		if err != nil {
+			return nil, fmt.Errorf("-inject %q: %w", item, err)
+		}
+		s.inject[p] = status
+	}
+	return s, nil
+}
+
+// parseInjection parses serie:number=status.
+func parseInjection(item string) (passport, int, error) {
+	key, value, ok := strings.Cut(item, "=")
+	if !ok {
+		return passport{}, 0, errors.New("want serie:number=status")
+	}
+	serie, number, ok := strings.Cut(key, ":")
+	if !ok {
+		return passport{}, 0, errors.New("want serie:number=status")
+	}
+	p, err := parsePassport(serie, number)
+	if err != nil {
+		return passport{}, 0, err
+	}
+	status, err := strconv.Atoi(value)
+	if err != nil || status < 100 || status > 599 {
+		return passport{}, 0, fmt.Errorf("%q is not an HTTP status", value)
+	}
+	return p, status, nil
+}
+
+func parsePassport(serie, number string) (passport, error) {
+	s, err := strconv.ParseInt(serie, 10, 32)
+	if err != nil {
+		return passport{}, fmt.Errorf("passportSerie %q is not an integer", serie)
+	}
+	n, err := strconv.ParseInt(number, 10, 32)
+	if err != nil {
+		return passport{}, fmt.Errorf("passportNumber %q is not an integer", number)
+	}
+	return passport{serie: int32(s), number: int32(n)}, nil
+}
+
+func (s *fakeServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/info", s.info)
+	return mux
+}
+
+func (s *fakeServer) info(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	delay, roll := s.draw()
+	if delay > 0 {
+		t := time.NewTimer(delay)
+		defer t.Stop()
+		select {
+		case <-r.Context().Done():
+			return
+		case <-t.C:
+		}
+	}
+
+	status, person := s.answer(r, roll)
+	s.l.Debug("fakeinfo - info",
+		zap.String("query", r.URL.RawQuery),
+		zap.Int("status", status),
+		zap.Duration("delay", delay),
+	)
+	if person == nil {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(swagger.People{
+		Surname:    person.Surname,
+		Name:       person.Name,
+		Patronymic: person.Patronymic,
+		Address:    person.Address,
+	})
+}
+
+// answer picks the response: injected failures for the passport first, then bad
+// parameters, then random failures, then the fixtures.
+func (s *fakeServer) answer(r *http.Request, roll float64) (int, *service.Person) {
+	query := r.URL.Query()
+	p, err := parsePassport(query.Get("passportSerie"), query.Get("passportNumber"))
+	if err != nil {
+		return http.StatusBadRequest, nil
+	}
+	if status, ok := s.inject[p]; ok {
+		return status, nil
+	}
+	switch {
+	case roll < s.errorRate:
+		return http.StatusInternalServerError, nil
+	case roll < s.errorRate+s.badRequestRate:
+		return http.StatusBadRequest, nil
+	}
+
+	person, err := s.people.InfoGet(r.Context(), p.serie, p.number)
+	if err != nil {
+		// the API contract only defines 200, 400 and 500
+		if s.notFound {
+			return http.StatusNotFound, nil
+		}
+		return http.StatusBadRequest, nil
+	}
+	return http.StatusOK, person
+}
+
+// draw takes the delay and the failure roll of a request from the seeded source.
+func (s *fakeServer) draw() (time.Duration, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delay := s.latency
+	if s.jitter > 0 {
+		delay += time.Duration(s.rnd.Int63n(int64(s.jitter) + 1))
+	}
+	return delay, s.rnd.Float64()
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogoalish/timetracker/config"
+	"github.com/gogoalish/timetracker/internal/clients"
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// startFakeInfo serves the fixture file like the command does and returns the config
+// of a client pointed at it, with the count of requests answered.
+func startFakeInfo(t *testing.T, s *fakeServer) (*config.Config, *atomic.Int32) {
+	var calls atomic.Int32
+	handler := s.handler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/info" {
+			calls.Add(1)
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return &config.Config{
+		APIURL: srv.URL,
+		API: config.APIConfig{
+			Providers: []string{"http"},
+			Timeout:   time.Second,
+			Retries:   2,
+		},
+	}, &calls
+}
+
+func newTestFakeServer(t *testing.T, inject string) *fakeServer {
+	s, err := newFakeServer("people.json", inject, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestAPIServiceAgainstFakeInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(s *fakeServer)
+		timeout     time.Duration
+		serie       int32
+		number      int32
+		wantSurname string
+		wantErr     error
+		wantCalls   int32
+	}{
+		{
+			name:        "known passport",
+			serie:       1234,
+			number:      567892,
+			wantSurname: "Сидоров",
+			wantCalls:   1,
+		},
+		{
+			name:      "unknown passport is a bad request as in the contract",
+			serie:     1,
+			number:    1,
+			wantErr:   service.ErrBadRequest,
+			wantCalls: 1,
+		},
+		{
+			name:      "unknown passport with -not-found",
+			setup:     func(s *fakeServer) { s.notFound = true },
+			serie:     1,
+			number:    1,
+			wantErr:   service.ErrApiNotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "injected 500 is retried",
+			serie:     1234,
+			number:    567890,
+			wantErr:   service.ErrApiInternal,
+			wantCalls: 3,
+		},
+		{
+			name:      "injected 400",
+			serie:     1234,
+			number:    567891,
+			wantErr:   service.ErrBadRequest,
+			wantCalls: 1,
+		},
+		{
+			name:      "error rate",
+			setup:     func(s *fakeServer) { s.errorRate = 1 },
+			serie:     1234,
+			number:    567892,
+			wantErr:   service.ErrApiInternal,
+			wantCalls: 3,
+		},
+		{
+			name:      "bad request rate",
+			setup:     func(s *fakeServer) { s.badRequestRate = 1 },
+			serie:     1234,
+			number:    567892,
+			wantErr:   service.ErrBadRequest,
+			wantCalls: 1,
+		},
+		{
+			name:      "latency beyond the client timeout",
+			setup:     func(s *fakeServer) { s.latency = 200 * time.Millisecond },
+			timeout:   20 * time.Millisecond,
+			serie:     1234,
+			number:    567892,
+			wantErr:   service.ErrApiTimeout,
+			wantCalls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestFakeServer(t, "1234:567890=500,1234:567891=400")
+			if tt.setup != nil {
+				tt.setup(s)
+			}
+			cfg, calls := startFakeInfo(t, s)
+			if tt.timeout > 0 {
+				cfg.API.Timeout = tt.timeout
+			}
+			api, err := clients.NewAPIService(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			person, err := api.InfoGet(context.Background(), tt.serie, tt.number)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("InfoGet() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || person.Surname != tt.wantSurname {
+				t.Fatalf("InfoGet() = %+v, %v, want surname %s", person, err, tt.wantSurname)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestProviderChainAgainstFakeInfo(t *testing.T) {
+	cfg, _ := startFakeInfo(t, newTestFakeServer(t, "2345:111222=500,1234:567890=500"))
+	cfg.API.Providers = []string{"http", "static"}
+	chain, err := clients.NewRegistry().NewChain(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		serie       int32
+		number      int32
+		wantSurname string
+		wantErr     error
+	}{
+		{name: "found by the API", serie: 1234, number: 567892, wantSurname: "Сидоров"},
+		{name: "API failure wins over not found", serie: 2345, number: 111222, wantErr: service.ErrApiInternal},
+		{name: "API failure, found in the fixtures", serie: 1234, number: 567890, wantSurname: "Иванов"},
+		{name: "rejected by the API, found in the fixtures", serie: 4321, number: 100200, wantSurname: "Smith"},
+		{name: "unknown to both", serie: 1, number: 1, wantErr: service.ErrBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			person, err := chain.InfoGet(context.Background(), tt.serie, tt.number)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("InfoGet() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || person.Surname != tt.wantSurname {
+				t.Fatalf("InfoGet() = %+v, %v, want surname %s", person, err, tt.wantSurname)
+			}
+		})
+	}
+}
+
+func TestFakeServerSeed(t *testing.T) {
+	statuses := func() []int {
+		s := newTestFakeServer(t, "")
+		s.errorRate = 0.5
+		var got []int
+		for i := 0; i < 20; i++ {
+			r := httptest.NewRequest(http.MethodGet, "/info?passportSerie=1234&passportNumber=567892", nil)
+			_, roll := s.draw()
+			status, _ := s.answer(r, roll)
+			got = append(got, status)
+		}
+		return got
+	}
+
+	first, second := statuses(), statuses()
+	failed := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("request %d: status %d, then %d with the same seed", i, first[i], second[i])
+		}
+		if first[i] == http.StatusInternalServerError {
+			failed++
+		}
+	}
+	if failed == 0 || failed == len(first) {
+		t.Errorf("%d of %d requests failed with error rate 0.5", failed, len(first))
+	}
+}
+
+func TestParseInjection(t *testing.T) {
+	tests := []struct {
+		item       string
+		want       passport
+		wantStatus int
+		wantErr    bool
+	}{
+		{item: "1234:567890=500", want: passport{serie: 1234, number: 567890}, wantStatus: 500},
+		{item: "1:2=400", want: passport{serie: 1, number: 2}, wantStatus: 400},
+		{item: "1234:567890", wantErr: true},
+		{item: "1234=500", wantErr: true},
+		{item: "a:1=500", wantErr: true},
+		{item: "1:2=ok", wantErr: true},
+		{item: "1:2=99", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.item, func(t *testing.T) {
+			p, status, err := parseInjection(tt.item)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseInjection() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (p != tt.want || status != tt.wantStatus) {
+				t.Errorf("parseInjection() = %v, %d, want %v, %d", p, status, tt.want, tt.wantStatus)
+			}
+		})
+	}
+}
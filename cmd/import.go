@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// runImport implements the import subcommand:
+//
+//	main import -format toggl -file entries.json [-dry-run]
+func runImport(svc service.ImportService, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "csv", "export format: csv, toggl or clockify")
+	path := fs.String("file", "", "path to the export file")
+	dryRun := fs.Bool("dry-run", false, "only report the entries that would fail")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	f, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, err := svc.Import(context.Background(), *format, f, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"expvar"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/gogoalish/timetracker/config"
 	_ "github.com/gogoalish/timetracker/docs"
@@ -55,22 +60,102 @@ func main() {
 	}
 
 	peopleRepo := repo.NewPeopleRepo(db)
-	apiClient, err := clients.NewAPIService(cfg)
+	apiClient, err := clients.NewRegistry().NewChain(cfg)
 	if err != nil {
 		l.Fatal(fmt.Sprint("error api client init: ", err))
 	}
-	peopleSvc := service.NewPeopleService(peopleRepo, apiClient)
+	var peopleInfoCacheRepo repo.PeopleInfoCacheRepo
+	if cfg.API.CacheDB {
+		peopleInfoCacheRepo = repo.NewPeopleInfoCacheRepo(db)
+		_, err = peopleInfoCacheRepo.DeleteExpiredPeopleInfoCache(context.Background(), time.Now())
+		if err != nil {
+			l.Fatal(fmt.Sprint("error purging people info cache: ", err))
+		}
+	}
+	cachedApiClient := clients.NewCachedAPIService(apiClient, peopleInfoCacheRepo, cfg.API)
+	expvar.Publish("people_info_cache", expvar.Func(func() any {
+		return cachedApiClient.Stats()
+	}))
+	peopleSvc := service.NewPeopleService(peopleRepo)
+
+	enrichmentRepo := repo.NewEnrichmentRepo(db)
+	enrichmentWorker := service.NewEnrichmentWorker(enrichmentRepo, cachedApiClient, cfg.Enrichment)
 
 	tasksRepo := repo.NewTasksRepo(db)
 	tasksSvc := service.NewTasksService(tasksRepo)
 
+	reportsRepo := repo.NewReportsRepo(db)
+	reportsSvc := service.NewReportsService(reportsRepo)
+
+	exportRepo := repo.NewExportRepo(db)
+	exportSvc := service.NewExportService(exportRepo, reportsSvc)
+
+	importSvc := service.NewImportService(tasksRepo, peopleRepo)
+
+	projectsRepo := repo.NewProjectsRepo(db)
+	projectsSvc := service.NewProjectsService(projectsRepo)
+
+	ratesRepo := repo.NewRatesRepo(db)
+	ratesSvc := service.NewRatesService(ratesRepo)
+
+	invoicesRepo := repo.NewInvoicesRepo(db)
+	invoicesSvc := service.NewInvoicesService(invoicesRepo)
+
+	timesheetsRepo := repo.NewTimesheetsRepo(db)
+	timesheetsSvc := service.NewTimesheetsService(timesheetsRepo)
+
+	locksRepo := repo.NewLocksRepo(db)
+	locksSvc := service.NewLocksService(locksRepo)
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		err = runImport(importSvc, os.Args[2:])
+		if err != nil {
+			l.Fatal(fmt.Sprint("error importing: ", err))
+		}
+		return
+	}
+
+	workerCtx, stopWorker := context.WithCancel(logger.WithLogger(context.Background(), l))
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		if cfg.Enrichment.Interval > 0 {
+			enrichmentWorker.Run(workerCtx)
+		}
+	}()
+
 	peopleController := controller.NewPeopleController(peopleSvc)
 	tasksController := controller.NewTasksController(tasksSvc)
-
-	router := server.NewRouter(peopleController, tasksController, l)
+	reportsController := controller.NewReportsController(reportsSvc)
+	exportController := controller.NewExportController(exportSvc)
+	importController := controller.NewImportController(importSvc)
+	projectsController := controller.NewProjectsController(projectsSvc)
+	ratesController := controller.NewRatesController(ratesSvc)
+	invoicesController := controller.NewInvoicesController(invoicesSvc)
+	timesheetsController := controller.NewTimesheetsController(timesheetsSvc)
+	locksController := controller.NewLocksController(locksSvc)
+
+	router := server.NewRouter(peopleController, tasksController, reportsController, exportController, importController, projectsController, ratesController, invoicesController, timesheetsController, locksController, l)
 	httpServer := server.New(cfg, router)
 	l.Info(fmt.Sprintf("server is listening on: http://%s:%s", cfg.Host, cfg.Port))
 
+	var debugServer *http.Server
+	if cfg.DebugAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		debugServer = &http.Server{
+			Addr:    cfg.DebugAddr,
+			Handler: mux,
+		}
+		go func() {
+			err := debugServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				l.Error(fmt.Sprint("main - debugServer.ListenAndServe: ", err))
+			}
+		}()
+		l.Info(fmt.Sprintf("debug server is listening on: http://%s/debug/vars", cfg.DebugAddr))
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
 
@@ -87,4 +172,14 @@ func main() {
 		l.Error(fmt.Sprint("main - httpServer.Shutdown: ", err))
 	}
 
+	if debugServer != nil {
+		err = debugServer.Close()
+		if err != nil {
+			l.Error(fmt.Sprint("main - debugServer.Close: ", err))
+		}
+	}
+
+	stopWorker()
+	<-workerDone
+
 }
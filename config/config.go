@@ -2,6 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/pkg/errors"
@@ -12,6 +15,59 @@ type Config struct {
 	APIURL string
 	Host   string
 	Port   string
+	// DebugAddr is where expvar counters, such as the people info cache hit rate,
+	// are served on /debug/vars. Empty disables it; keep it off the public network.
+	DebugAddr  string
+	API        APIConfig
+	Enrichment EnrichmentConfig
+}
+
+// APIConfig tunes the client of the people info API. Every field has a default,
+// so none of the API_* variables has to be set.
+type APIConfig struct {
+	// Providers are the names of the people info providers to ask, in order:
+	// http for the people info API, file for the records in ProvidersFile and
+	// static for built-in fixtures.
+	Providers     []string
+	ProvidersFile string
+	// Timeout bounds every single call, retries get a fresh timeout.
+	Timeout time.Duration
+	// Retries is how many times a call failing with a network error, a timeout
+	// or a 5xx response is repeated.
+	Retries int
+	// Backoff is the wait before the first retry, doubled for every next one
+	// up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// After BreakerThreshold failed calls in a row the client stops calling
+	// the API for BreakerCooldown and fails fast instead.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// CacheSize is how many lookups are kept in memory, zero disables the cache.
+	// Found people are cached for CacheTTL, unknown passports for CacheNegativeTTL.
+	CacheSize        int
+	CacheTTL         time.Duration
+	CacheNegativeTTL time.Duration
+	// CacheDB keeps the lookups in Postgres too, so they survive restarts.
+	CacheDB bool
+}
+
+// EnrichmentConfig tunes the background worker filling in new people from the people info API.
+type EnrichmentConfig struct {
+	// Interval is how often the worker looks for due jobs, taking up to Batch at a time.
+	// Zero disables the worker, leaving the jobs to other instances.
+	Interval time.Duration
+	Batch    int
+	// MaxAttempts is how many times a person is looked up before enrichment fails.
+	MaxAttempts int
+	// Backoff is the wait before the second attempt, doubled for every next one
+	// up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Lease is how long a job is hidden from other workers once its processing starts,
+	// at least as long as a lookup with all its retries. A job whose worker died is
+	// picked up again once the lease runs out.
+	Lease time.Duration
 }
 
 func New() (*Config, error) {
@@ -20,10 +76,137 @@ func New() (*Config, error) {
 		return nil, errors.Wrap(err, "error loading .env:")
 	}
 
+	api, err := newAPIConfig()
+	if err != nil {
+		return nil, err
+	}
+	enrichment, err := newEnrichmentConfig()
+	if err != nil {
+		return nil, err
+	}
+	// a job must not be taken over by another worker while its lookup is still retrying
+	if lookup := api.Timeout*time.Duration(api.Retries+1) + api.MaxBackoff*time.Duration(api.Retries); api.Timeout > 0 && enrichment.Lease < lookup {
+		return nil, errors.Errorf("error parsing ENRICH_LEASE: %s is shorter than a people info lookup with retries, %s", enrichment.Lease, lookup)
+	}
+
 	return &Config{
-		DBURL:  os.Getenv("DB_URL"),
-		APIURL: os.Getenv("API_URL"),
-		Host:   os.Getenv("HOST"),
-		Port:   os.Getenv("PORT"),
+		DBURL:      os.Getenv("DB_URL"),
+		APIURL:     os.Getenv("API_URL"),
+		Host:       os.Getenv("HOST"),
+		Port:       os.Getenv("PORT"),
+		DebugAddr:  os.Getenv("DEBUG_ADDR"),
+		API:        api,
+		Enrichment: enrichment,
 	}, nil
 }
+
+func newAPIConfig() (cfg APIConfig, err error) {
+	cfg.Providers = listEnv("API_PROVIDERS", []string{"http"})
+	cfg.ProvidersFile = os.Getenv("API_PROVIDERS_FILE")
+	if cfg.Timeout, err = durationEnv("API_TIMEOUT", 5*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.Retries, err = intEnv("API_RETRIES", 2); err != nil {
+		return cfg, err
+	}
+	if cfg.Backoff, err = durationEnv("API_BACKOFF", 200*time.Millisecond); err != nil {
+		return cfg, err
+	}
+	if cfg.MaxBackoff, err = durationEnv("API_MAX_BACKOFF", 2*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.BreakerThreshold, err = intEnv("API_BREAKER_THRESHOLD", 5); err != nil {
+		return cfg, err
+	}
+	if cfg.BreakerCooldown, err = durationEnv("API_BREAKER_COOLDOWN", 30*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.CacheSize, err = intEnv("API_CACHE_SIZE", 1000); err != nil {
+		return cfg, err
+	}
+	if cfg.CacheTTL, err = durationEnv("API_CACHE_TTL", 24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.CacheNegativeTTL, err = durationEnv("API_CACHE_NEGATIVE_TTL", 10*time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.CacheDB, err = boolEnv("API_CACHE_DB", false); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func newEnrichmentConfig() (cfg EnrichmentConfig, err error) {
+	if cfg.Interval, err = durationEnv("ENRICH_INTERVAL", 2*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.Batch, err = intEnv("ENRICH_BATCH", 10); err != nil {
+		return cfg, err
+	}
+	if cfg.Batch < 1 {
+		return cfg, errors.Errorf("error parsing ENRICH_BATCH: %d is not a positive integer", cfg.Batch)
+	}
+	if cfg.MaxAttempts, err = intEnv("ENRICH_MAX_ATTEMPTS", 5); err != nil {
+		return cfg, err
+	}
+	if cfg.Backoff, err = durationEnv("ENRICH_BACKOFF", 30*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.MaxBackoff, err = durationEnv("ENRICH_MAX_BACKOFF", 30*time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.Lease, err = durationEnv("ENRICH_LEASE", time.Minute); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func durationEnv(key string, def time.Duration) (time.Duration, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, errors.Errorf("error parsing %s: %q is not a non-negative duration", key, s)
+	}
+	return d, nil
+}
+
+func intEnv(key string, def int) (int, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf("error parsing %s: %q is not a non-negative integer", key, s)
+	}
+	return n, nil
+}
+
+func boolEnv(key string, def bool) (bool, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errors.Errorf("error parsing %s: %q is not a boolean", key, s)
+	}
+	return b, nil
+}
+
+func listEnv(key string, def []string) []string {
+	s := os.Getenv(key)
+	if s == "" {
+		return def
+	}
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,114 @@
+package clients
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker is a circuit breaker. It opens after threshold failures in a row and
+// rejects calls until cooldown has passed, then lets a single trial call through:
+// the breaker closes again if it succeeds and reopens if it fails.
+// A zero threshold disables the breaker.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a call may be made. Every allowed call must be followed
+// by a call to record, neutral or abandon.
+func (b *breaker) allow() bool {
+	if b.threshold == 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// the trial call is still in flight
+		return false
+	}
+	return true
+}
+
+func (b *breaker) record(failed bool) {
+	if b.threshold == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// neutral is called instead of record when the API answered with something that says
+// nothing about its health, such as a rejected request. It doesn't reset the failures
+// in a row, but a trial call answered this way shows the API is back.
+func (b *breaker) neutral() {
+	if b.threshold == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerClosed
+	}
+}
+
+// abandon is called instead of record when the caller gave up on the call, which
+// says nothing about the health of the API. An abandoned trial call lets the next
+// call try again.
+func (b *breaker) abandon() {
+	if b.threshold == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
@@ -0,0 +1,124 @@
+package clients
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	type step struct {
+		op   string // allow, fail, ok, neutral, abandon or wait
+		want bool   // result of allow
+	}
+	tests := []struct {
+		name      string
+		threshold int
+		steps     []step
+	}{
+		{
+			name:      "disabled",
+			threshold: 0,
+			steps: []step{
+				{op: "fail"}, {op: "fail"}, {op: "fail"},
+				{op: "allow", want: true},
+			},
+		},
+		{
+			name:      "opens after threshold failures in a row",
+			threshold: 2,
+			steps: []step{
+				{op: "allow", want: true}, {op: "fail"},
+				{op: "allow", want: true}, {op: "fail"},
+				{op: "allow", want: false},
+			},
+		},
+		{
+			name:      "success resets the failures",
+			threshold: 2,
+			steps: []step{
+				{op: "fail"}, {op: "ok"}, {op: "fail"},
+				{op: "allow", want: true},
+			},
+		},
+		{
+			name:      "neutral keeps the failures",
+			threshold: 2,
+			steps: []step{
+				{op: "fail"}, {op: "neutral"}, {op: "fail"},
+				{op: "allow", want: false},
+			},
+		},
+		{
+			name:      "half-open lets a single trial through",
+			threshold: 1,
+			steps: []step{
+				{op: "fail"}, {op: "wait"},
+				{op: "allow", want: true},
+				{op: "allow", want: false},
+			},
+		},
+		{
+			name:      "failed trial reopens",
+			threshold: 1,
+			steps: []step{
+				{op: "fail"}, {op: "wait"},
+				{op: "allow", want: true}, {op: "fail"},
+				{op: "allow", want: false},
+			},
+		},
+		{
+			name:      "successful trial closes",
+			threshold: 1,
+			steps: []step{
+				{op: "fail"}, {op: "wait"},
+				{op: "allow", want: true}, {op: "ok"},
+				{op: "allow", want: true},
+			},
+		},
+		{
+			name:      "neutral trial closes",
+			threshold: 1,
+			steps: []step{
+				{op: "fail"}, {op: "wait"},
+				{op: "allow", want: true}, {op: "neutral"},
+				{op: "allow", want: true},
+			},
+		},
+		{
+			name:      "abandoned trial lets the next call try",
+			threshold: 1,
+			steps: []step{
+				{op: "fail"}, {op: "wait"},
+				{op: "allow", want: true}, {op: "abandon"},
+				{op: "allow", want: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			b := newBreaker(tt.threshold, time.Minute)
+			b.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				switch s.op {
+				case "allow":
+					if got := b.allow(); got != s.want {
+						t.Fatalf("step %d: allow() = %v, want %v", i, got, s.want)
+					}
+				case "fail":
+					b.record(true)
+				case "ok":
+					b.record(false)
+				case "neutral":
+					b.neutral()
+				case "abandon":
+					b.abandon()
+				case "wait":
+					now = now.Add(time.Minute)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package clients
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogoalish/timetracker/config"
+	"github.com/gogoalish/timetracker/internal/repo"
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// Results of a lookup as kept in the people_info_cache table.
+const (
+	resultFound      = "found"
+	resultNotFound   = "not_found"
+	resultBadRequest = "bad_request"
+)
+
+type passportKey struct {
+	serie  int32
+	number int32
+}
+
+type cacheEntry struct {
+	key       passportKey
+	person    *service.Person
+	err       error
+	expiresAt time.Time
+}
+
+// CacheStats are the counters of a CachedAPIService since it was created.
+// Hits include the negative ones and those served from Postgres.
+type CacheStats struct {
+	Hits         int64   `json:"hits"`
+	NegativeHits int64   `json:"negative_hits"`
+	StoreHits    int64   `json:"store_hits"`
+	Misses       int64   `json:"misses"`
+	Evictions    int64   `json:"evictions"`
+	StoreErrors  int64   `json:"store_errors"`
+	Size         int     `json:"size"`
+	HitRate      float64 `json:"hit_rate"`
+}
+
+// CachedAPIService is a service.ApiClient that remembers the lookups of the wrapped client
+// in an in-memory LRU and, given a store, in Postgres. Unknown passports and rejected
+// requests are cached too, for a shorter time; failures of the API never are.
+type CachedAPIService struct {
+	api         service.ApiClient
+	store       repo.PeopleInfoCacheRepo
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[passportKey]*list.Element
+	order   *list.List // most recently used first
+
+	hits         atomic.Int64
+	negativeHits atomic.Int64
+	storeHits    atomic.Int64
+	misses       atomic.Int64
+	evictions    atomic.Int64
+	storeErrors  atomic.Int64
+}
+
+// NewCachedAPIService wraps api with a cache. store may be nil to keep the cache in memory only.
+func NewCachedAPIService(api service.ApiClient, store repo.PeopleInfoCacheRepo, cfg config.APIConfig) *CachedAPIService {
+	return &CachedAPIService{
+		api:         api,
+		store:       store,
+		size:        cfg.CacheSize,
+		ttl:         cfg.CacheTTL,
+		negativeTTL: cfg.CacheNegativeTTL,
+		now:         time.Now,
+		entries:     make(map[passportKey]*list.Element),
+		order:       list.New(),
+	}
+}
+
+func (s *CachedAPIService) InfoGet(ctx context.Context, passportSerie int32, passportNumber int32) (*service.Person, error) {
+	key := passportKey{serie: passportSerie, number: passportNumber}
+	if entry, ok := s.get(key); ok {
+		return s.hit(entry)
+	}
+	if entry, ok := s.load(ctx, key); ok {
+		s.storeHits.Add(1)
+		s.put(entry)
+		return s.hit(entry)
+	}
+	s.misses.Add(1)
+
+	person, err := s.api.InfoGet(ctx, passportSerie, passportNumber)
+	entry := cacheEntry{
+		key:       key,
+		person:    person,
+		err:       err,
+		expiresAt: s.now().Add(s.ttl),
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrApiNotFound), errors.Is(err, service.ErrBadRequest):
+		entry.expiresAt = s.now().Add(s.negativeTTL)
+	default:
+		return nil, err
+	}
+	if entry.expiresAt.After(s.now()) {
+		s.put(entry)
+		s.save(ctx, entry)
+	}
+	return copyPerson(person), err
+}
+
+// Stats returns the counters of the cache, safe to call concurrently with InfoGet.
+func (s *CachedAPIService) Stats() CacheStats {
+	s.mu.Lock()
+	size := s.order.Len()
+	s.mu.Unlock()
+
+	stats := CacheStats{
+		Hits:         s.hits.Load(),
+		NegativeHits: s.negativeHits.Load(),
+		StoreHits:    s.storeHits.Load(),
+		Misses:       s.misses.Load(),
+		Evictions:    s.evictions.Load(),
+		StoreErrors:  s.storeErrors.Load(),
+		Size:         size,
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+func (s *CachedAPIService) hit(entry cacheEntry) (*service.Person, error) {
+	s.hits.Add(1)
+	if entry.err != nil {
+		s.negativeHits.Add(1)
+	}
+	return copyPerson(entry.person), entry.err
+}
+
+func (s *CachedAPIService) get(key passportKey) (cacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	entry := elem.Value.(cacheEntry)
+	if !entry.expiresAt.After(s.now()) {
+		s.order.Remove(elem)
+		delete(s.entries, key)
+		return cacheEntry{}, false
+	}
+	s.order.MoveToFront(elem)
+	return entry, true
+}
+
+func (s *CachedAPIService) put(entry cacheEntry) {
+	if s.size <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[entry.key]; ok {
+		elem.Value = entry
+		s.order.MoveToFront(elem)
+		return
+	}
+	s.entries[entry.key] = s.order.PushFront(entry)
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(cacheEntry).key)
+		s.evictions.Add(1)
+	}
+}
+
+// load looks the key up in the store. Store errors are counted and treated as misses,
+// the cache must never be the reason a lookup fails.
+func (s *CachedAPIService) load(ctx context.Context, key passportKey) (cacheEntry, bool) {
+	if s.store == nil {
+		return cacheEntry{}, false
+	}
+
+	row, err := s.store.GetPeopleInfoCache(ctx, repo.GetPeopleInfoCacheParams{
+		PassportSerie:  key.serie,
+		PassportNumber: key.number,
+		ExpiresAt:      s.now(),
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.storeErrors.Add(1)
+		}
+		return cacheEntry{}, false
+	}
+
+	entry := cacheEntry{
+		key:       key,
+		expiresAt: row.ExpiresAt,
+	}
+	switch row.Result {
+	case resultFound:
+		entry.person = &service.Person{
+			Surname:    row.Surname,
+			Name:       row.Name,
+			Patronymic: row.Patronymic,
+			Address:    row.Address,
+		}
+	case resultNotFound:
+		entry.err = &APIError{Err: service.ErrApiNotFound, StatusCode: http.StatusNotFound}
+	default:
+		entry.err = &APIError{Err: service.ErrBadRequest, StatusCode: http.StatusBadRequest}
+	}
+	return entry, true
+}
+
+func (s *CachedAPIService) save(ctx context.Context, entry cacheEntry) {
+	if s.store == nil {
+		return
+	}
+
+	arg := repo.UpsertPeopleInfoCacheParams{
+		PassportSerie:  entry.key.serie,
+		PassportNumber: entry.key.number,
+		ExpiresAt:      entry.expiresAt,
+	}
+	switch {
+	case entry.err == nil:
+		arg.Result = resultFound
+		arg.Surname = entry.person.Surname
+		arg.Name = entry.person.Name
+		arg.Patronymic = entry.person.Patronymic
+		arg.Address = entry.person.Address
+	case errors.Is(entry.err, service.ErrApiNotFound):
+		arg.Result = resultNotFound
+	default:
+		arg.Result = resultBadRequest
+	}
+	if err := s.store.UpsertPeopleInfoCache(ctx, arg); err != nil {
+		s.storeErrors.Add(1)
+	}
+}
+
+// copyPerson keeps callers from changing the cached person.
+func copyPerson(person *service.Person) *service.Person {
+	if person == nil {
+		return nil
+	}
+	p := *person
+	return &p
+}
@@ -0,0 +1,270 @@
+package clients
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogoalish/timetracker/config"
+	"github.com/gogoalish/timetracker/internal/repo"
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// Passports of the upstream of the cache tests, by number.
+const (
+	knownNumber   = 1
+	unknownNumber = 2
+	failingNumber = 3
+)
+
+// countingAPI knows knownNumber, fails on failingNumber and counts the calls.
+type countingAPI struct {
+	calls int
+}
+
+func (a *countingAPI) InfoGet(ctx context.Context, passportSerie int32, passportNumber int32) (*service.Person, error) {
+	a.calls++
+	switch passportNumber {
+	case knownNumber:
+		return &service.Person{Surname: "Иванов", Name: "Иван", Address: "Москва"}, nil
+	case failingNumber:
+		return nil, &APIError{Err: service.ErrApiInternal, StatusCode: 500}
+	}
+	return nil, &APIError{Err: service.ErrApiNotFound, StatusCode: 404}
+}
+
+// memoryStore is a repo.PeopleInfoCacheRepo in a map.
+type memoryStore struct {
+	rows map[passportKey]repo.PeopleInfoCache
+	err  error
+}
+
+func (s *memoryStore) GetPeopleInfoCache(ctx context.Context, arg repo.GetPeopleInfoCacheParams) (repo.PeopleInfoCache, error) {
+	if s.err != nil {
+		return repo.PeopleInfoCache{}, s.err
+	}
+	row, ok := s.rows[passportKey{serie: arg.PassportSerie, number: arg.PassportNumber}]
+	if !ok || !row.ExpiresAt.After(arg.ExpiresAt) {
+		return repo.PeopleInfoCache{}, sql.ErrNoRows
+	}
+	return row, nil
+}
+
+func (s *memoryStore) UpsertPeopleInfoCache(ctx context.Context, arg repo.UpsertPeopleInfoCacheParams) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.rows[passportKey{serie: arg.PassportSerie, number: arg.PassportNumber}] = repo.PeopleInfoCache(arg)
+	return nil
+}
+
+func (s *memoryStore) DeleteExpiredPeopleInfoCache(ctx context.Context, expiresAt time.Time) (int64, error) {
+	return 0, nil
+}
+
+type cacheClock struct {
+	now time.Time
+}
+
+func newTestCache(api service.ApiClient, store repo.PeopleInfoCacheRepo, cfg config.APIConfig) (*CachedAPIService, *cacheClock) {
+	clock := &cacheClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewCachedAPIService(api, store, cfg)
+	s.now = func() time.Time { return clock.now }
+	return s, clock
+}
+
+func TestCachedAPIService(t *testing.T) {
+	cfg := config.APIConfig{
+		CacheSize:        10,
+		CacheTTL:         time.Hour,
+		CacheNegativeTTL: 10 * time.Minute,
+	}
+	type lookup struct {
+		wait      time.Duration // before the lookup
+		number    int32
+		wantErr   error
+		wantCalls int // upstream calls so far
+	}
+	tests := []struct {
+		name      string
+		size      int
+		negTTL    time.Duration
+		lookups   []lookup
+		wantStats CacheStats
+	}{
+		{
+			name: "found is cached for the ttl",
+			lookups: []lookup{
+				{number: knownNumber, wantCalls: 1},
+				{wait: 59 * time.Minute, number: knownNumber, wantCalls: 1},
+				{wait: time.Minute, number: knownNumber, wantCalls: 2},
+			},
+			wantStats: CacheStats{Hits: 1, Misses: 2, Size: 1, HitRate: 1.0 / 3},
+		},
+		{
+			name: "not found is cached for the negative ttl",
+			lookups: []lookup{
+				{number: unknownNumber, wantErr: service.ErrApiNotFound, wantCalls: 1},
+				{wait: 9 * time.Minute, number: unknownNumber, wantErr: service.ErrApiNotFound, wantCalls: 1},
+				{wait: time.Minute, number: unknownNumber, wantErr: service.ErrApiNotFound, wantCalls: 2},
+			},
+			wantStats: CacheStats{Hits: 1, NegativeHits: 1, Misses: 2, Size: 1, HitRate: 1.0 / 3},
+		},
+		{
+			name:   "zero negative ttl doesn't cache not found",
+			negTTL: -1,
+			lookups: []lookup{
+				{number: unknownNumber, wantErr: service.ErrApiNotFound, wantCalls: 1},
+				{number: unknownNumber, wantErr: service.ErrApiNotFound, wantCalls: 2},
+			},
+			wantStats: CacheStats{Misses: 2},
+		},
+		{
+			name: "failures are never cached",
+			lookups: []lookup{
+				{number: failingNumber, wantErr: service.ErrApiInternal, wantCalls: 1},
+				{number: failingNumber, wantErr: service.ErrApiInternal, wantCalls: 2},
+			},
+			wantStats: CacheStats{Misses: 2},
+		},
+		{
+			name: "least recently used is evicted",
+			size: 2,
+			lookups: []lookup{
+				{number: knownNumber, wantCalls: 1},
+				{number: unknownNumber, wantErr: service.ErrApiNotFound, wantCalls: 2},
+				{number: knownNumber, wantCalls: 2},
+				{number: 4, wantErr: service.ErrApiNotFound, wantCalls: 3},
+				{number: knownNumber, wantCalls: 3},
+				{number: unknownNumber, wantErr: service.ErrApiNotFound, wantCalls: 4},
+			},
+			wantStats: CacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2, HitRate: 2.0 / 6},
+		},
+		{
+			name: "zero size disables the cache",
+			size: -1,
+			lookups: []lookup{
+				{number: knownNumber, wantCalls: 1},
+				{number: knownNumber, wantCalls: 2},
+			},
+			wantStats: CacheStats{Misses: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := cfg
+			if tt.size != 0 {
+				cfg.CacheSize = max(tt.size, 0)
+			}
+			if tt.negTTL != 0 {
+				cfg.CacheNegativeTTL = max(tt.negTTL, 0)
+			}
+			api := &countingAPI{}
+			s, clock := newTestCache(api, nil, cfg)
+
+			for i, l := range tt.lookups {
+				clock.now = clock.now.Add(l.wait)
+				person, err := s.InfoGet(context.Background(), 1234, l.number)
+				if l.wantErr != nil {
+					if !errors.Is(err, l.wantErr) {
+						t.Fatalf("lookup %d: InfoGet() error = %v, want %v", i, err, l.wantErr)
+					}
+				} else if err != nil || person.Surname != "Иванов" {
+					t.Fatalf("lookup %d: InfoGet() = %+v, %v", i, person, err)
+				}
+				if api.calls != l.wantCalls {
+					t.Fatalf("lookup %d: %d upstream calls, want %d", i, api.calls, l.wantCalls)
+				}
+			}
+			if got := s.Stats(); got != tt.wantStats {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestCachedAPIServiceReturnsCopies(t *testing.T) {
+	s, _ := newTestCache(&countingAPI{}, nil, config.APIConfig{CacheSize: 10, CacheTTL: time.Hour})
+	person, _ := s.InfoGet(context.Background(), 1234, knownNumber)
+	person.Surname = "Петров"
+
+	person, _ = s.InfoGet(context.Background(), 1234, knownNumber)
+	if person.Surname != "Иванов" {
+		t.Errorf("cached person changed to %+v", person)
+	}
+}
+
+func TestCachedAPIServiceStore(t *testing.T) {
+	cfg := config.APIConfig{
+		CacheSize:        10,
+		CacheTTL:         time.Hour,
+		CacheNegativeTTL: 10 * time.Minute,
+	}
+	tests := []struct {
+		name      string
+		number    int32
+		wait      time.Duration // between the lookups of the two caches
+		storeErr  error
+		wantErr   error
+		wantCalls int
+		wantStats CacheStats
+	}{
+		{
+			name:      "found survives a restart",
+			number:    knownNumber,
+			wantCalls: 1,
+			wantStats: CacheStats{Hits: 1, StoreHits: 1, Size: 1, HitRate: 1},
+		},
+		{
+			name:      "not found survives a restart",
+			number:    unknownNumber,
+			wantErr:   service.ErrApiNotFound,
+			wantCalls: 1,
+			wantStats: CacheStats{Hits: 1, NegativeHits: 1, StoreHits: 1, Size: 1, HitRate: 1},
+		},
+		{
+			name:      "expired rows are ignored",
+			number:    knownNumber,
+			wait:      time.Hour,
+			wantCalls: 2,
+			wantStats: CacheStats{Misses: 1, Size: 1},
+		},
+		{
+			name:      "store errors fall back to the API",
+			number:    knownNumber,
+			storeErr:  errors.New("connection refused"),
+			wantCalls: 2,
+			wantStats: CacheStats{Misses: 1, StoreErrors: 2, Size: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &countingAPI{}
+			store := &memoryStore{rows: make(map[passportKey]repo.PeopleInfoCache)}
+			first, clock := newTestCache(api, store, cfg)
+			first.InfoGet(context.Background(), 1234, tt.number)
+
+			store.err = tt.storeErr
+			second, _ := newTestCache(api, store, cfg)
+			second.now = func() time.Time { return clock.now.Add(tt.wait) }
+			person, err := second.InfoGet(context.Background(), 1234, tt.number)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("InfoGet() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || person.Surname != "Иванов" {
+				t.Fatalf("InfoGet() = %+v, %v", person, err)
+			}
+			if api.calls != tt.wantCalls {
+				t.Errorf("%d upstream calls, want %d", api.calls, tt.wantCalls)
+			}
+			if got := second.Stats(); got != tt.wantStats {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+type namedProvider struct {
+	name string
+	api  service.ApiClient
+}
+
+// ProviderChain is a service.ApiClient asking several providers in priority order.
+// Each field of the person is taken from the first provider that has it, and providers
+// are asked until surname, name and address are all known; patronymic is optional.
+type ProviderChain struct {
+	providers []namedProvider
+}
+
+// Add appends a provider with a lower priority than those added before.
+func (c *ProviderChain) Add(name string, api service.ApiClient) {
+	c.providers = append(c.providers, namedProvider{name: name, api: api})
+}
+
+// InfoGet merges what the providers know about the person. If none knows the person,
+// a provider failure is returned in preference to not-found, since the failed provider
+// might have known them.
+func (c *ProviderChain) InfoGet(ctx context.Context, passportSerie int32, passportNumber int32) (*service.Person, error) {
+	var merged *service.Person
+	var failure, rejection error
+	for _, p := range c.providers {
+		person, err := p.api.InfoGet(ctx, passportSerie, passportNumber)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			err = fmt.Errorf("%s: %w", p.name, err)
+			switch {
+			case errors.Is(err, service.ErrApiNotFound), errors.Is(err, service.ErrBadRequest):
+				if rejection == nil {
+					rejection = err
+				}
+			default:
+				if failure == nil {
+					failure = err
+				}
+			}
+			continue
+		}
+
+		if merged == nil {
+			merged = &service.Person{}
+		}
+		mergePerson(merged, person)
+		if merged.Surname != "" && merged.Name != "" && merged.Address != "" {
+			break
+		}
+	}
+
+	switch {
+	case merged != nil:
+		return merged, nil
+	case failure != nil:
+		return nil, failure
+	case rejection != nil:
+		return nil, rejection
+	}
+	return nil, &APIError{Err: service.ErrApiNotFound}
+}
+
+// mergePerson fills the empty fields of dst from src.
+func mergePerson(dst, src *service.Person) {
+	if dst.Surname == "" {
+		dst.Surname = src.Surname
+	}
+	if dst.Name == "" {
+		dst.Name = src.Name
+	}
+	if dst.Patronymic == "" {
+		dst.Patronymic = src.Patronymic
+	}
+	if dst.Address == "" {
+		dst.Address = src.Address
+	}
+}
@@ -0,0 +1,256 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogoalish/timetracker/config"
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// fakeProvider answers every lookup with the same person or error and counts the calls.
+type fakeProvider struct {
+	person *service.Person
+	err    error
+	calls  int
+}
+
+func (p *fakeProvider) InfoGet(ctx context.Context, passportSerie int32, passportNumber int32) (*service.Person, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	person := *p.person
+	return &person, nil
+}
+
+func found(surname, name, patronymic, address string) *fakeProvider {
+	return &fakeProvider{person: &service.Person{Surname: surname, Name: name, Patronymic: patronymic, Address: address}}
+}
+
+func failing(err error) *fakeProvider {
+	return &fakeProvider{err: &APIError{Err: err}}
+}
+
+func TestProviderChainInfoGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []*fakeProvider
+		want      *service.Person
+		wantErr   error
+		// wantCalls are the calls made to each provider
+		wantCalls []int
+	}{
+		{
+			name:      "first complete answer wins",
+			providers: []*fakeProvider{found("Иванов", "Иван", "Иванович", "Москва"), found("Петров", "Пётр", "", "Казань")},
+			want:      &service.Person{Surname: "Иванов", Name: "Иван", Patronymic: "Иванович", Address: "Москва"},
+			wantCalls: []int{1, 0},
+		},
+		{
+			name:      "missing patronymic doesn't ask further",
+			providers: []*fakeProvider{found("Smith", "John", "", "London"), found("Smith", "John", "Jr", "London")},
+			want:      &service.Person{Surname: "Smith", Name: "John", Address: "London"},
+			wantCalls: []int{1, 0},
+		},
+		{
+			name:      "fields are merged in priority order",
+			providers: []*fakeProvider{found("Иванов", "", "", ""), found("Петров", "Иван", "", ""), found("Сидоров", "Пётр", "Иванович", "Москва")},
+			want:      &service.Person{Surname: "Иванов", Name: "Иван", Patronymic: "Иванович", Address: "Москва"},
+			wantCalls: []int{1, 1, 1},
+		},
+		{
+			name:      "failures are skipped",
+			providers: []*fakeProvider{failing(service.ErrApiInternal), failing(service.ErrApiNotFound), found("Иванов", "Иван", "", "Москва")},
+			want:      &service.Person{Surname: "Иванов", Name: "Иван", Address: "Москва"},
+			wantCalls: []int{1, 1, 1},
+		},
+		{
+			name:      "partial answer beats failures",
+			providers: []*fakeProvider{found("Иванов", "", "", ""), failing(service.ErrApiTimeout)},
+			want:      &service.Person{Surname: "Иванов"},
+			wantCalls: []int{1, 1},
+		},
+		{
+			name:      "failure wins over not found",
+			providers: []*fakeProvider{failing(service.ErrApiNotFound), failing(service.ErrApiTimeout), failing(service.ErrApiInternal)},
+			wantErr:   service.ErrApiTimeout,
+			wantCalls: []int{1, 1, 1},
+		},
+		{
+			name:      "first rejection when all reject",
+			providers: []*fakeProvider{failing(service.ErrBadRequest), failing(service.ErrApiNotFound)},
+			wantErr:   service.ErrBadRequest,
+			wantCalls: []int{1, 1},
+		},
+		{
+			name:      "no providers",
+			providers: nil,
+			wantErr:   service.ErrApiNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &ProviderChain{}
+			for i, p := range tt.providers {
+				chain.Add(string(rune('a'+i)), p)
+			}
+
+			person, err := chain.InfoGet(context.Background(), 1234, 567890)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("InfoGet() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || *person != *tt.want {
+				t.Fatalf("InfoGet() = %+v, %v, want %+v", person, err, tt.want)
+			}
+			for i, p := range tt.providers {
+				if p.calls != tt.wantCalls[i] {
+					t.Errorf("provider %d called %d times, want %d", i, p.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProviderChainCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	chain := &ProviderChain{}
+	chain.Add("a", failing(service.ErrApiTimeout))
+	chain.Add("b", found("Иванов", "Иван", "", "Москва"))
+
+	if _, err := chain.InfoGet(ctx, 1234, 567890); !errors.Is(err, context.Canceled) {
+		t.Fatalf("InfoGet() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    *service.Person
+		wantErr bool
+	}{
+		{
+			name:    "json",
+			file:    "people.json",
+			content: `[{"passport_serie":1234,"passport_number":567890,"surname":"Иванов","name":"Иван","patronymic":"Иванович","address":"Москва"}]`,
+			want:    &service.Person{Surname: "Иванов", Name: "Иван", Patronymic: "Иванович", Address: "Москва"},
+		},
+		{
+			name:    "csv in any column order",
+			file:    "people.csv",
+			content: "address,name,surname,passport_number,passport_serie\n\"Москва, ул. Ленина\",Иван,Иванов,567890,1234\n",
+			want:    &service.Person{Surname: "Иванов", Name: "Иван", Address: "Москва, ул. Ленина"},
+		},
+		{
+			name:    "csv missing a column",
+			file:    "people.csv",
+			content: "passport_serie,passport_number,surname,name\n1234,567890,Иванов,Иван\n",
+			wantErr: true,
+		},
+		{
+			name:    "csv bad passport",
+			file:    "people.csv",
+			content: "passport_serie,passport_number,surname,name,address\n12a4,567890,Иванов,Иван,Москва\n",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			file:    "people.txt",
+			content: "Иванов Иван",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := NewFileProvider(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFileProvider() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			person, err := p.InfoGet(context.Background(), 1234, 567890)
+			if err != nil || *person != *tt.want {
+				t.Fatalf("InfoGet() = %+v, %v, want %+v", person, err, tt.want)
+			}
+			if _, err := p.InfoGet(context.Background(), 1234, 1); !errors.Is(err, service.ErrApiNotFound) {
+				t.Errorf("InfoGet() of an unknown passport error = %v, want %v", err, service.ErrApiNotFound)
+			}
+		})
+	}
+}
+
+func TestRegistryNewChain(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []string
+		file      string
+		wantErr   bool
+	}{
+		{name: "static", providers: []string{"static"}},
+		{name: "http and static", providers: []string{"http", "static"}},
+		{name: "file without a path", providers: []string{"file"}, wantErr: true},
+		{name: "unknown provider", providers: []string{"static", "ldap"}, wantErr: true},
+		{name: "none", providers: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				APIURL: "http://localhost:8081",
+				API: config.APIConfig{
+					Providers:     tt.providers,
+					ProvidersFile: tt.file,
+				},
+			}
+			_, err := NewRegistry().NewChain(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewChain() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaticProvider(t *testing.T) {
+	p := NewStaticProvider(append(Fixtures, Record{PassportSerie: 1234, PassportNumber: 567890, Surname: "Иванова", Name: "Анна", Address: "Тверь"})...)
+	tests := []struct {
+		name        string
+		serie       int32
+		number      int32
+		wantSurname string
+		wantErr     error
+	}{
+		{name: "fixture", serie: 4321, number: 100200, wantSurname: "Smith"},
+		{name: "later record replaces earlier", serie: 1234, number: 567890, wantSurname: "Иванова"},
+		{name: "unknown", serie: 4321, number: 1, wantErr: service.ErrApiNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			person, err := p.InfoGet(context.Background(), tt.serie, tt.number)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("InfoGet() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || person.Surname != tt.wantSurname {
+				t.Fatalf("InfoGet() = %+v, %v, want surname %s", person, err, tt.wantSurname)
+			}
+		})
+	}
+}
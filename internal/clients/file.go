@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Columns of a CSV people file, the header row may list them in any order.
+var recordColumns = []string{"passport_serie", "passport_number", "surname", "name", "patronymic", "address"}
+
+// NewFileProvider answers from the records in a .json file, holding an array of Record,
+// or a .csv file with a header row naming the Record fields. The file is read once.
+func NewFileProvider(path string) (*StaticProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.NewDecoder(f).Decode(&records)
+	case ".csv":
+		records, err = readRecordsCSV(f)
+	default:
+		return nil, fmt.Errorf("people file %s: unsupported format, want .json or .csv", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("people file %s: %w", path, err)
+	}
+	return NewStaticProvider(records...), nil
+}
+
+func readRecordsCSV(r io.Reader) ([]Record, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+	for _, name := range recordColumns {
+		if _, ok := index[name]; !ok && name != "patronymic" {
+			return nil, fmt.Errorf("missing column %s", name)
+		}
+	}
+
+	records := []Record{}
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		serie, err := strconv.ParseInt(field("passport_serie"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: passport_serie: %w", line, err)
+		}
+		number, err := strconv.ParseInt(field("passport_number"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: passport_number: %w", line, err)
+		}
+		records = append(records, Record{
+			PassportSerie:  int32(serie),
+			PassportNumber: int32(number),
+			Surname:        field("surname"),
+			Name:           field("name"),
+			Patronymic:     field("patronymic"),
+			Address:        field("address"),
+		})
+	}
+}
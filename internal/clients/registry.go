@@ -0,0 +1,67 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogoalish/timetracker/config"
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// ProviderFactory builds a people info provider from the configuration.
+type ProviderFactory func(cfg *config.Config) (service.ApiClient, error)
+
+// Registry maps provider names, as listed in API_PROVIDERS, to their factories.
+type Registry struct {
+	factories map[string]ProviderFactory
+}
+
+// NewRegistry returns a registry knowing the http, file and static providers.
+func NewRegistry() *Registry {
+	r := &Registry{
+		factories: make(map[string]ProviderFactory),
+	}
+	r.Register("http", func(cfg *config.Config) (service.ApiClient, error) {
+		api, err := NewAPIService(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return api, nil
+	})
+	r.Register("file", func(cfg *config.Config) (service.ApiClient, error) {
+		if cfg.API.ProvidersFile == "" {
+			return nil, errors.New("file provider needs API_PROVIDERS_FILE")
+		}
+		return NewFileProvider(cfg.API.ProvidersFile)
+	})
+	r.Register("static", func(cfg *config.Config) (service.ApiClient, error) {
+		return NewStaticProvider(Fixtures...), nil
+	})
+	return r
+}
+
+// Register adds a provider, replacing any registered under the same name.
+func (r *Registry) Register(name string, factory ProviderFactory) {
+	r.factories[name] = factory
+}
+
+// NewChain builds the providers listed in the configuration into a chain, in that order.
+func (r *Registry) NewChain(cfg *config.Config) (*ProviderChain, error) {
+	if len(cfg.API.Providers) == 0 {
+		return nil, errors.New("no people info providers configured")
+	}
+
+	chain := &ProviderChain{}
+	for _, name := range cfg.API.Providers {
+		factory, ok := r.factories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown people info provider %q", name)
+		}
+		api, err := factory(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("people info provider %s: %w", name, err)
+		}
+		chain.Add(name, api)
+	}
+	return chain, nil
+}
@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// Record is a person as kept by the file and static providers.
+type Record struct {
+	PassportSerie  int32  `json:"passport_serie"`
+	PassportNumber int32  `json:"passport_number"`
+	Surname        string `json:"surname"`
+	Name           string `json:"name"`
+	Patronymic     string `json:"patronymic,omitempty"`
+	Address        string `json:"address"`
+}
+
+// Fixtures are the people known to the static provider, for running without the people info API.
+var Fixtures = []Record{
+	{PassportSerie: 1234, PassportNumber: 567890, Surname: "Иванов", Name: "Иван", Patronymic: "Иванович", Address: "г. Москва, ул. Ленина, д. 5, кв. 1"},
+	{PassportSerie: 1234, PassportNumber: 567891, Surname: "Петрова", Name: "Мария", Patronymic: "Сергеевна", Address: "г. Казань, ул. Баумана, д. 12, кв. 4"},
+	{PassportSerie: 4321, PassportNumber: 100200, Surname: "Smith", Name: "John", Address: "221B Baker Street, London"},
+}
+
+// StaticProvider is a service.ApiClient answering from a fixed set of records.
+type StaticProvider struct {
+	people map[passportKey]service.Person
+}
+
+// NewStaticProvider indexes the records by passport, later records replacing earlier ones.
+func NewStaticProvider(records ...Record) *StaticProvider {
+	p := &StaticProvider{
+		people: make(map[passportKey]service.Person, len(records)),
+	}
+	for _, r := range records {
+		p.people[passportKey{serie: r.PassportSerie, number: r.PassportNumber}] = service.Person{
+			Surname:    r.Surname,
+			Name:       r.Name,
+			Patronymic: r.Patronymic,
+			Address:    r.Address,
+		}
+	}
+	return p
+}
+
+func (p *StaticProvider) InfoGet(ctx context.Context, passportSerie int32, passportNumber int32) (*service.Person, error) {
+	person, ok := p.people[passportKey{serie: passportSerie, number: passportNumber}]
+	if !ok {
+		return nil, &APIError{Err: service.ErrApiNotFound, StatusCode: http.StatusNotFound}
+	}
+	return &person, nil
+}
@@ -2,8 +2,12 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/gogoalish/timetracker/config"
 	"github.com/gogoalish/timetracker/internal/clients/swagger"
@@ -11,7 +15,40 @@ import (
 )
 
 type APIService struct {
-	client *swagger.APIClient
+	client  *swagger.APIClient
+	cfg     config.APIConfig
+	breaker *breaker
+}
+
+// APIError is returned by InfoGet when the people info API call fails. Err is one of
+// service.ErrApiNotFound, ErrBadRequest, ErrApiThrottled, ErrApiTimeout, ErrApiInternal
+// and ErrApiUnavailable, so callers can match it with errors.Is.
+type APIError struct {
+	Err error
+	// StatusCode is the status of the last response, zero if there was none.
+	StatusCode int
+	// Attempts is the number of calls made, zero when the circuit breaker is open.
+	Attempts int
+	// Cause is the error the last call failed with, if any.
+	Cause error
+}
+
+func (e *APIError) Error() string {
+	msg := e.Err.Error()
+	if e.StatusCode != 0 {
+		msg = fmt.Sprintf("%s: status %d", msg, e.StatusCode)
+	}
+	if e.Cause != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Cause)
+	}
+	if e.Attempts > 1 {
+		msg = fmt.Sprintf("%s (after %d attempts)", msg, e.Attempts)
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
 }
 
 func NewAPIService(cfg *config.Config) (*APIService, error) {
@@ -25,28 +62,128 @@ func NewAPIService(cfg *config.Config) (*APIService, error) {
 
 	client := swagger.NewAPIClient(swagCfg)
 
-	return &APIService{client}, nil
+	return &APIService{
+		client:  client,
+		cfg:     cfg.API,
+		breaker: newBreaker(cfg.API.BreakerThreshold, cfg.API.BreakerCooldown),
+	}, nil
 }
 
+// InfoGet looks a person up by passport. Network errors, timeouts and 5xx responses
+// are retried with exponential backoff. They and 429 responses count as failures of the
+// circuit breaker; once it is open the call fails with service.ErrApiUnavailable without
+// reaching the API.
 func (s *APIService) InfoGet(ctx context.Context, passportSerie int32, passportNumber int32) (*service.Person, error) {
+	var apiErr *APIError
+	for attempt := 0; attempt <= s.cfg.Retries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, s.backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+		if !s.breaker.allow() {
+			return nil, &APIError{
+				Err:      service.ErrApiUnavailable,
+				Attempts: attempt,
+			}
+		}
+
+		person, err := s.infoGet(ctx, passportSerie, passportNumber)
+		if ctx.Err() != nil {
+			s.breaker.abandon()
+			return nil, ctx.Err()
+		}
+		if err == nil {
+			s.breaker.record(false)
+			return person, nil
+		}
+
+		errors.As(err, &apiErr)
+		apiErr.Attempts = attempt + 1
+		switch {
+		case retryable(apiErr.Err):
+			s.breaker.record(true)
+			continue
+		case errors.Is(apiErr.Err, service.ErrApiThrottled):
+			// being throttled, the API wants fewer calls, not another one right away
+			s.breaker.record(true)
+		default:
+			s.breaker.neutral()
+		}
+		break
+	}
+	return nil, apiErr
+}
+
+// infoGet makes a single call, bounded by the configured timeout.
+func (s *APIService) infoGet(ctx context.Context, passportSerie int32, passportNumber int32) (*service.Person, error) {
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
 	person, resp, err := s.client.DefaultApi.InfoGet(ctx, passportSerie, passportNumber)
-	if err != nil {
-		return nil, err
+	if resp == nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, &APIError{Err: service.ErrApiTimeout, Cause: err}
+		}
+		return nil, &APIError{Err: service.ErrApiInternal, Cause: err}
 	}
-	defer resp.Body.Close()
 
-	switch resp.StatusCode {
-	case http.StatusOK:
+	switch {
+	case resp.StatusCode == http.StatusOK && err == nil:
 		return &service.Person{
 			Surname:    person.Surname,
 			Name:       person.Name,
 			Patronymic: person.Patronymic,
 			Address:    person.Address,
 		}, nil
-	case http.StatusBadRequest:
-		return nil, service.ErrBadRequest
-	default:
-		return nil, service.ErrApiInternal
+	case resp.StatusCode == http.StatusBadRequest:
+		return nil, &APIError{Err: service.ErrBadRequest, StatusCode: resp.StatusCode}
+	case resp.StatusCode == http.StatusNotFound:
+		// an extension: the API contract only defines 200, 400 and 500, but should the
+		// API answer 404, it can only mean there is no such person
+		return nil, &APIError{Err: service.ErrApiNotFound, StatusCode: resp.StatusCode}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, &APIError{Err: service.ErrApiThrottled, StatusCode: resp.StatusCode}
+	case resp.StatusCode == http.StatusGatewayTimeout:
+		return nil, &APIError{Err: service.ErrApiTimeout, StatusCode: resp.StatusCode}
 	}
+	// 5xx, and anything else the API is not supposed to answer
+	return nil, &APIError{Err: service.ErrApiInternal, StatusCode: resp.StatusCode, Cause: err}
+}
+
+// retryable tells the failures worth another try: the API may have been briefly down
+// or slow. Rejected requests would only be rejected again.
+func retryable(err error) bool {
+	return errors.Is(err, service.ErrApiInternal) || errors.Is(err, service.ErrApiTimeout)
+}
 
+// backoff is the wait before the given retry: Backoff doubled for every earlier retry,
+// capped at MaxBackoff, of which a random half is skipped to spread retries of
+// concurrent calls.
+func (s *APIService) backoff(attempt int) time.Duration {
+	d := s.cfg.Backoff
+	for i := 1; i < attempt && (s.cfg.MaxBackoff == 0 || d < s.cfg.MaxBackoff); i++ {
+		d *= 2
+	}
+	if s.cfg.MaxBackoff > 0 && d > s.cfg.MaxBackoff {
+		d = s.cfg.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
@@ -0,0 +1,107 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogoalish/timetracker/config"
+	"github.com/gogoalish/timetracker/internal/service"
+)
+
+// newTestAPIService returns a client of a server answering with statuses in turn,
+// repeating the last one, and the count of calls made.
+func newTestAPIService(t *testing.T, cfg config.APIConfig, statuses ...int) (*APIService, *int) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"surname":"Иванов","name":"Иван","address":"г. Москва"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	s, err := NewAPIService(&config.Config{APIURL: srv.URL, API: cfg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, &calls
+}
+
+func TestAPIServiceInfoGet(t *testing.T) {
+	cfg := config.APIConfig{
+		Timeout: time.Second,
+		Retries: 2,
+	}
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "found", statuses: []int{200}, wantCalls: 1},
+		{name: "retried until found", statuses: []int{500, 503, 200}, wantCalls: 3},
+		{name: "retries run out", statuses: []int{500}, wantErr: service.ErrApiInternal, wantCalls: 3},
+		{name: "gateway timeout is retried", statuses: []int{504, 200}, wantCalls: 2},
+		{name: "bad request", statuses: []int{400}, wantErr: service.ErrBadRequest, wantCalls: 1},
+		{name: "not found", statuses: []int{404}, wantErr: service.ErrApiNotFound, wantCalls: 1},
+		{name: "throttled", statuses: []int{429}, wantErr: service.ErrApiThrottled, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, calls := newTestAPIService(t, cfg, tt.statuses...)
+			person, err := s.InfoGet(context.Background(), 1234, 567890)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("InfoGet() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && person.Surname != "Иванов" {
+				t.Errorf("InfoGet() = %+v", person)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestAPIServiceBreaker(t *testing.T) {
+	cfg := config.APIConfig{
+		Timeout:          time.Second,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Hour,
+	}
+	tests := []struct {
+		name     string
+		statuses []int
+	}{
+		{name: "internal errors", statuses: []int{500, 500}},
+		{name: "throttling", statuses: []int{429, 429}},
+		{name: "rejections don't reset failures", statuses: []int{500, 404, 400, 500}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, calls := newTestAPIService(t, cfg, tt.statuses...)
+			for range tt.statuses {
+				s.InfoGet(context.Background(), 1234, 567890)
+			}
+			n := *calls
+
+			_, err := s.InfoGet(context.Background(), 1234, 567890)
+			if !errors.Is(err, service.ErrApiUnavailable) {
+				t.Fatalf("InfoGet() error = %v, want %v", err, service.ErrApiUnavailable)
+			}
+			if *calls != n {
+				t.Errorf("InfoGet() called the API with the breaker open")
+			}
+		})
+	}
+}
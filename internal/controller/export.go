@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/exporter"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type ExportController struct {
+	svc service.ExportService
+}
+
+func NewExportController(svc service.ExportService) *ExportController {
+	return &ExportController{
+		svc: svc,
+	}
+}
+
+type exportReq struct {
+	Format  string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	Columns string `form:"columns"`
+}
+
+type exportTasksReq struct {
+	exportReq
+	UserID int    `form:"user_id" binding:"omitempty,min=1"`
+	FromDT string `form:"from_dt" binding:"required"`
+	ToDT   string `form:"to_dt" binding:"required"`
+	Clip   bool   `form:"clip"`
+}
+
+// Tasks godoc
+// @Summary Export tasks
+// @Description Export the finished tasks of a person, or of everyone when user_id is omitted, as CSV or XLSX.
+// @Description Available columns: id, user_id, person, surname, name, patronymic, description, status, start_dt, end_dt, total_seconds, duration, hours.
+// @Tags Export
+// @Produce  text/csv
+// @Produce  application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param user_id query int false "Person ID"
+// @Param from_dt query string true "Range start, 2006-01-02 15:04:05"
+// @Param to_dt query string true "Range end, 2006-01-02 15:04:05"
+// @Param format query string false "csv (default) or xlsx"
+// @Param columns query string false "Comma separated list of columns, all by default"
+// @Param clip query bool false "Include every task overlapping the range with its time clipped to it, running sessions up to now"
+// @Success 200 {file} file "Exported tasks"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /export/tasks [get]
+func (c *ExportController) Tasks(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req exportTasksReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ExportController - Tasks - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	from, to, err := parseRange(req.FromDT, req.ToDT)
+	if err != nil {
+		l.Error("ExportController - Tasks - time parsing error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Exporting tasks", zap.Any("request", req))
+
+	stream(ctx, l, "ExportTasks", req.exportReq, "tasks", func(w exporter.Writer) error {
+		return c.svc.ExportTasks(ctx, w, exporter.ParseColumns(req.Columns), req.UserID, from, to, req.Clip)
+	})
+}
+
+type exportPersonReportReq struct {
+	exportReq
+	personReportReq
+}
+
+// PersonReport godoc
+// @Summary Export a person's time report
+// @Description Export the report of /reports/people/{id} as CSV or XLSX, one row per task and period.
+// @Description Available columns: bucket_start, bucket_end, bucket_total_seconds, bucket_duration, bucket_amount, task_id, description, total_seconds, duration, amount.
+// @Tags Export
+// @Produce  text/csv
+// @Produce  application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Person ID"
+// @Param from_dt query string true "Range start, 2006-01-02 15:04:05"
+// @Param to_dt query string true "Range end, 2006-01-02 15:04:05"
+// @Param period query string false "Bucket size: day (default), week or month"
+// @Param format query string false "csv (default) or xlsx"
+// @Param columns query string false "Comma separated list of columns, all by default"
+// @Param clip query bool false "Count running sessions up to now"
+// @Success 200 {file} file "Exported report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Person not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /export/reports/people/{id} [get]
+func (c *ExportController) PersonReport(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri personUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ExportController - PersonReport - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req exportPersonReportReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ExportController - PersonReport - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Period == "" {
+		req.Period = service.PeriodDay
+	}
+	from, to, err := parseRange(req.FromDT, req.ToDT)
+	if err != nil {
+		l.Error("ExportController - PersonReport - time parsing error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Exporting person report", zap.Int("user_id", uri.UserID), zap.Any("request", req))
+
+	stream(ctx, l, "ExportPersonReport", req.exportReq, fmt.Sprintf("report-person-%d", uri.UserID), func(w exporter.Writer) error {
+		return c.svc.ExportPersonReport(ctx, w, exporter.ParseColumns(req.Columns), uri.UserID, req.Period, from, to, req.Clip)
+	})
+}
+
+type exportTeamReportReq struct {
+	exportReq
+	teamReportReq
+}
+
+// TeamReport godoc
+// @Summary Export the team workload report
+// @Description Export the report of /reports/team as CSV or XLSX, one row per person.
+// @Description Available columns: id, person, surname, name, patronymic, address, task_count, total_seconds, duration, longest_task_id, longest_task_seconds, average_task_seconds, amount.
+// @Tags Export
+// @Produce  text/csv
+// @Produce  application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param from_dt query string true "Range start, 2006-01-02 15:04:05"
+// @Param to_dt query string true "Range end, 2006-01-02 15:04:05"
+// @Param sort_by query string false "total_seconds, task_count, longest_task_seconds, average_task_seconds, surname or name"
+// @Param order query string false "asc or desc"
+// @Param limit query int false "Limit"
+// @Param page query int false "Page"
+// @Param passport_serie query int false "Passport Serie"
+// @Param passport_number query int false "Passport Number"
+// @Param surname query string false "Surname"
+// @Param name query string false "Name"
+// @Param patronymic query string false "Patronymic"
+// @Param address query string false "Address"
+// @Param format query string false "csv (default) or xlsx"
+// @Param columns query string false "Comma separated list of columns, all by default"
+// @Param clip query bool false "Count running sessions up to now"
+// @Success 200 {file} file "Exported report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /export/reports/team [get]
+func (c *ExportController) TeamReport(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req exportTeamReportReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ExportController - TeamReport - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	from, to, err := parseRange(req.FromDT, req.ToDT)
+	if err != nil {
+		l.Error("ExportController - TeamReport - time parsing error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Exporting team report", zap.Any("request", req))
+
+	filter := service.Filter{
+		Limit:          req.Limit,
+		Offset:         req.Page,
+		PassportSerie:  req.PassportSerie,
+		PassportNumber: req.PassportNumber,
+		Surname:        req.Surname,
+		Name:           req.Name,
+		Patronymic:     req.Patronymic,
+		Address:        req.Address,
+	}
+	stream(ctx, l, "ExportTeamReport", req.exportReq, "report-team", func(w exporter.Writer) error {
+		return c.svc.ExportTeamReport(ctx, w, exporter.ParseColumns(req.Columns), filter, req.SortBy, req.Order != "asc", from, to, req.Clip)
+	})
+}
+
+// stream writes the export straight to the response. Errors raised before anything
+// reached the client are reported as JSON, later ones can only be logged.
+func stream(ctx *gin.Context, l *zap.Logger, op string, req exportReq, name string, fn func(exporter.Writer) error) {
+	format := req.Format
+	if format == "" {
+		format = exporter.FormatCSV
+	}
+
+	w, err := exporter.New(format, ctx.Writer)
+	if err != nil {
+		l.Error("ExportController - "+op+" - exporter error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Type", exporter.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+format))
+	ctx.Status(http.StatusOK)
+
+	err = fn(w)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		l.Error("ExportController - "+op+" error", zap.Error(err))
+		if ctx.Writer.Written() {
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		switch {
+		case errors.Is(err, service.ErrNoResult):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, service.ErrInvalidRange),
+			errors.Is(err, service.ErrInvalidPeriod),
+			errors.Is(err, service.ErrInvalidSort),
+			errors.Is(err, exporter.ErrUnknownColumn):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Export written successfully", zap.String("name", name), zap.String("format", format))
+}
+
+func parseRange(fromDT, toDT string) (time.Time, time.Time, error) {
+	from, err := parseTime(fromDT)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("from_dt: %w", err)
+	}
+	to, err := parseTime(toDT)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("to_dt: %w", err)
+	}
+	return from, to, nil
+}
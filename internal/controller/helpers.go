@@ -1,7 +1,35 @@
 package controller
 
-import "github.com/gin-gonic/gin"
+import (
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02 15:04:05"
 
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
+
+// personUri binds the person ID of /people/{id}/... style routes.
+type personUri struct {
+	UserID int `uri:"id" binding:"required,min=1"`
+}
+
+// idUri binds the ID of /clients/{id}, /projects/{id}, /rates/{id}, /admin/locks/{id} and /people/{id}/schedule style routes.
+type idUri struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// hoursToSeconds converts the hours of estimates and budgets to whole seconds.
+func hoursToSeconds(hours float64) int64 {
+	return int64(math.Round(hours * 3600))
+}
+
+// parseTime parses a dateLayout timestamp in local time, the zone tasks are tracked in,
+// so it compares right with the stored times and time.Now().
+func parseTime(s string) (time.Time, error) {
+	return parseTime(s)
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/importer"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type ImportController struct {
+	svc service.ImportService
+}
+
+func NewImportController(svc service.ImportService) *ImportController {
+	return &ImportController{
+		svc: svc,
+	}
+}
+
+type importReq struct {
+	Format string `form:"format" binding:"required,oneof=csv toggl clockify"`
+	DryRun bool   `form:"dry_run"`
+}
+
+// Import godoc
+// @Summary Import time entries
+// @Description Import historical time entries from a CSV file or a Toggl / Clockify JSON export, creating one finished task per entry.
+// @Description CSV columns: description, start, end and either passport_serie + passport_number or person (or surname, name, patronymic).
+// @Description Entries are matched to people by passport or full name; those that can't be imported are skipped and listed in the report.
+// @Description With dry_run nothing is written.
+// @Tags Tasks
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param   file  formData  file  true  "Export file"
+// @Param   format  formData  string  true  "csv, toggl or clockify"
+// @Param   dry_run  formData  bool  false  "Only validate the entries"
+// @Success 200 {object} service.ImportReport "Import report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/import [post]
+func (c *ImportController) Import(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req importReq
+	if err := ctx.ShouldBind(&req); err != nil {
+		l.Error("ImportController - Import - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		l.Error("ImportController - Import - file error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		l.Error("ImportController - Import - file open error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	l.Debug("Importing time entries", zap.String("format", req.Format), zap.String("file", header.Filename), zap.Bool("dry_run", req.DryRun))
+
+	report, err := c.svc.Import(ctx, req.Format, file, req.DryRun)
+	if err != nil {
+		l.Error("ImportController - Import - Import error", zap.Error(err))
+		if errors.Is(err, importer.ErrUnknownFormat) || errors.Is(err, importer.ErrMalformed) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Time entries imported", zap.Int("total", report.Total), zap.Int("imported", report.Imported), zap.Int("failed", len(report.Failed)), zap.Bool("dry_run", report.DryRun))
+	ctx.JSON(http.StatusOK, report)
+}
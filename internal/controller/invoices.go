@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type InvoicesController struct {
+	svc service.InvoicesService
+}
+
+func NewInvoicesController(svc service.InvoicesService) *InvoicesController {
+	return &InvoicesController{
+		svc: svc,
+	}
+}
+
+type createInvoiceReq struct {
+	ClientID int32  `json:"client_id" binding:"required,min=1"`
+	FromDT   string `json:"from_dt" binding:"required"`
+	ToDT     string `json:"to_dt" binding:"required"`
+	TaxRate  string `json:"tax_rate"`
+}
+
+// Create godoc
+// @Summary Create an invoice
+// @Description Invoice the billable time of a client's projects over a date range, one line per project, person and rate.
+// @Description Only finished work sessions are billed. The invoice gets the next number and is stored as a snapshot
+// @Description that later changes to tasks, rates or names don't affect.
+// @Tags Invoices
+// @Accept  json
+// @Produce  json
+// @Param   invoice  body  createInvoiceReq  true  "Client, range as 2006-01-02 15:04:05 and tax rate in percent like 20 or 7.5"
+// @Success 200 {integer} int "Invoice ID"
+// @Failure 400 {object} map[string]interface{} "Invalid request or nothing to invoice"
+// @Failure 409 {object} map[string]interface{} "Range overlaps another invoice of the client"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /invoices [post]
+func (c *InvoicesController) Create(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req createInvoiceReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("InvoicesController - Create - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("InvoicesController - Create - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("InvoicesController - Create - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Creating invoice", zap.Any("request", req))
+
+	id, err := c.svc.CreateInvoice(ctx, req.ClientID, from, to, req.TaxRate)
+	if err != nil {
+		l.Error("InvoicesController - Create - CreateInvoice error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrAlreadyInvoiced):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, service.ErrNoResult),
+			errors.Is(err, service.ErrInvalidRange),
+			errors.Is(err, service.ErrInvalidTaxRate),
+			errors.Is(err, service.ErrNothingToInvoice):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Invoice created successfully", zap.Int32("invoice_id", id))
+	ctx.JSON(http.StatusOK, id)
+}
+
+type listInvoicesReq struct {
+	ClientID int32 `form:"client_id" binding:"omitempty,min=1"`
+}
+
+// List godoc
+// @Summary List invoices
+// @Description List invoices newest first, without their lines, optionally of one client
+// @Tags Invoices
+// @Produce  json
+// @Param client_id query int false "Client ID"
+// @Success 200 {array} service.Invoice "List of invoices"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /invoices [get]
+func (c *InvoicesController) List(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req listInvoicesReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("InvoicesController - List - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	invoices, err := c.svc.ListInvoices(ctx, req.ClientID)
+	if err != nil {
+		l.Error("InvoicesController - List - ListInvoices error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Invoices listed successfully", zap.Int("count", len(invoices)))
+	ctx.JSON(http.StatusOK, invoices)
+}
+
+// errInvalidInvoiceID is returned for /invoices/{id} paths that don't hold a positive ID.
+var errInvalidInvoiceID = errors.New("invalid invoice id")
+
+// Get godoc
+// @Summary Get an invoice
+// @Description Get an invoice with its lines by its ID
+// @Tags Invoices
+// @Produce  json
+// @Param   id  path  int  true  "Invoice ID"
+// @Success 200 {object} service.Invoice "Invoice"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Invoice not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /invoices/{id} [get]
+func (c *InvoicesController) Get(ctx *gin.Context) {
+	// gin can't route /invoices/:id.pdf next to /invoices/:id, so the PDF is served from here
+	if strings.HasSuffix(ctx.Param("id"), ".pdf") {
+		c.PDF(ctx)
+		return
+	}
+
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	id, err := invoiceID(ctx.Param("id"))
+	if err != nil {
+		l.Error("InvoicesController - Get - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	invoice, err := c.svc.GetInvoice(ctx, id)
+	if err != nil {
+		l.Error("InvoicesController - Get - GetInvoice error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Invoice fetched successfully", zap.Int32("invoice_id", id))
+	ctx.JSON(http.StatusOK, invoice)
+}
+
+// PDF godoc
+// @Summary Get an invoice as PDF
+// @Description Render an invoice as an A4 PDF document
+// @Tags Invoices
+// @Produce  application/pdf
+// @Param   id  path  int  true  "Invoice ID"
+// @Success 200 {file} file "Invoice document"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Invoice not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /invoices/{id}.pdf [get]
+func (c *InvoicesController) PDF(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	id, err := invoiceID(strings.TrimSuffix(ctx.Param("id"), ".pdf"))
+	if err != nil {
+		l.Error("InvoicesController - PDF - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	invoice, err := c.svc.GetInvoice(ctx, id)
+	if err != nil {
+		l.Error("InvoicesController - PDF - GetInvoice error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Type", "application/pdf")
+	ctx.Header("Content-Disposition", `inline; filename="`+invoice.Number+`.pdf"`)
+	ctx.Status(http.StatusOK)
+	if err := c.svc.WriteInvoicePDF(ctx.Writer, invoice); err != nil {
+		l.Error("InvoicesController - PDF - write error", zap.Error(err))
+		return
+	}
+
+	l.Info("Invoice rendered successfully", zap.Int32("invoice_id", id))
+}
+
+func invoiceID(param string) (int32, error) {
+	id, err := strconv.ParseInt(param, 10, 32)
+	if err != nil || id < 1 {
+		return 0, errInvalidInvoiceID
+	}
+	return int32(id), nil
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type LocksController struct {
+	svc service.LocksService
+}
+
+func NewLocksController(svc service.LocksService) *LocksController {
+	return &LocksController{
+		svc: svc,
+	}
+}
+
+type createLockReq struct {
+	UserID   int32  `json:"user_id" binding:"omitempty,min=1"`
+	FromDT   string `json:"from_dt" binding:"required"`
+	ToDT     string `json:"to_dt" binding:"required"`
+	LockedBy string `json:"locked_by" binding:"required"`
+	Reason   string `json:"reason"`
+}
+
+// Create godoc
+// @Summary Lock a period
+// @Description Close [from_dt, to_dt) for a person, or for everyone when user_id is omitted. Tasks can no longer be
+// @Description created, started, stopped, edited or deleted with time inside a locked period; such requests fail with 423.
+// @Tags Admin
+// @Accept  json
+// @Produce  json
+// @Param   lock  body  createLockReq  true  "Period, times as 2006-01-02 15:04:05"
+// @Success 200 {integer} int "Lock ID"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /admin/locks [post]
+func (c *LocksController) Create(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req createLockReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("LocksController - Create - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("LocksController - Create - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("LocksController - Create - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := c.svc.CreateLock(ctx, service.PeriodLock{
+		UserID:   req.UserID,
+		FromDt:   from,
+		ToDt:     to,
+		LockedBy: req.LockedBy,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		l.Error("LocksController - Create - CreateLock error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) || errors.Is(err, service.ErrInvalidRange) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Period locked successfully", zap.Int32("lock_id", id), zap.String("locked_by", req.LockedBy))
+	ctx.JSON(http.StatusOK, id)
+}
+
+type listLocksReq struct {
+	UserID int32 `form:"user_id" binding:"omitempty,min=1"`
+}
+
+// List godoc
+// @Summary List locked periods
+// @Description List locked periods, latest first. With user_id, only the locks applying to the person are listed,
+// @Description including the global ones.
+// @Tags Admin
+// @Produce  json
+// @Param user_id query int false "Person ID"
+// @Success 200 {array} service.PeriodLock "List of locks"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /admin/locks [get]
+func (c *LocksController) List(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req listLocksReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("LocksController - List - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	locks, err := c.svc.ListLocks(ctx, req.UserID)
+	if err != nil {
+		l.Error("LocksController - List - ListLocks error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Locks listed successfully", zap.Int("count", len(locks)))
+	ctx.JSON(http.StatusOK, locks)
+}
+
+// Delete godoc
+// @Summary Unlock a period
+// @Description Remove a lock, reopening its period unless another lock or an approved timesheet covers it
+// @Tags Admin
+// @Produce  json
+// @Param   id  path  int  true  "Lock ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Lock not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /admin/locks/{id} [delete]
+func (c *LocksController) Delete(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("LocksController - Delete - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.DeleteLock(ctx, uri.ID)
+	if err != nil {
+		l.Error("LocksController - Delete - DeleteLock error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Period unlocked successfully", zap.Int32("lock_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
@@ -31,7 +31,10 @@ var ErrNoLogger = errors.New("logger not found in context")
 
 // Create godoc
 // @Summary Create a new person
-// @Description Create a new person with given passport details
+// @Description Create a new person with given passport details. The person is created right away
+// @Description with enrichment_status pending; name, surname and address are filled in from the
+// @Description people info API in the background. Lookup failures don't fail the request, see
+// @Description enrichment_status and enrichment_error of the person instead.
 // @Tags People
 // @Accept json
 // @Produce json
@@ -59,6 +62,8 @@ func (c *PeopleController) Create(ctx *gin.Context) {
 	id, err := c.svc.CreatePerson(ctx, req.PassportSerie, req.PassportNumber)
 	if err != nil {
 		l.Error("PeopleCntrl - Create - CreatePerson error", zap.Error(err))
+		// CreatePerson no longer calls the people info API, so unknown passports and API
+		// failures can't happen here; they end up in enrichment_status and enrichment_error
 		if errors.Is(err, service.ErrAlreadyExists) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(err))
 			return
@@ -71,14 +76,15 @@ func (c *PeopleController) Create(ctx *gin.Context) {
 }
 
 type listPeopleReq struct {
-	Limit          *int32 `form:"limit" binding:"omitempty,min=1"`
-	Page           *int32 `form:"page" binding:"omitempty,min=1"`
-	PassportSerie  *int32 `form:"passport_serie" binding:"omitempty,min=1"`
-	PassportNumber *int32 `form:"passport_number" binding:"omitempty,min=1"`
-	Surname        string `form:"surname"`
-	Name           string `form:"name"`
-	Patronymic     string `form:"patronymic"`
-	Address        string `form:"address"`
+	Limit            *int32 `form:"limit" binding:"omitempty,min=1"`
+	Page             *int32 `form:"page" binding:"omitempty,min=1"`
+	PassportSerie    *int32 `form:"passport_serie" binding:"omitempty,min=1"`
+	PassportNumber   *int32 `form:"passport_number" binding:"omitempty,min=1"`
+	Surname          string `form:"surname"`
+	Name             string `form:"name"`
+	Patronymic       string `form:"patronymic"`
+	Address          string `form:"address"`
+	EnrichmentStatus string `form:"enrichment_status" binding:"omitempty,oneof=pending enriched failed"`
 }
 
 // List godoc
@@ -95,6 +101,7 @@ type listPeopleReq struct {
 // @Param name query string false "Name"
 // @Param patronymic query string false "Patronymic"
 // @Param address query string false "Address"
+// @Param enrichment_status query string false "Enrichment status: pending, enriched or failed"
 // @Success 200 {array} service.Person "List of people"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -116,17 +123,22 @@ func (c *PeopleController) List(ctx *gin.Context) {
 	l.Debug("Listing people with filters", zap.Any("filters", req))
 
 	people, err := c.svc.ListPeople(ctx, service.Filter{
-		Limit:          req.Limit,
-		Offset:         req.Page,
-		PassportSerie:  req.PassportSerie,
-		PassportNumber: req.PassportNumber,
-		Surname:        req.Surname,
-		Name:           req.Name,
-		Patronymic:     req.Patronymic,
-		Address:        req.Address,
+		Limit:            req.Limit,
+		Offset:           req.Page,
+		PassportSerie:    req.PassportSerie,
+		PassportNumber:   req.PassportNumber,
+		Surname:          req.Surname,
+		Name:             req.Name,
+		Patronymic:       req.Patronymic,
+		Address:          req.Address,
+		EnrichmentStatus: req.EnrichmentStatus,
 	})
 	if err != nil {
 		l.Error("PeopleCntrl - List - ListPeople error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidEnrichmentStatus) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -240,3 +252,150 @@ func (c *PeopleController) Delete(ctx *gin.Context) {
 	l.Info("Person deleted successfully", zap.Int32("id", req.ID))
 	ctx.Status(http.StatusOK)
 }
+
+// Schedule godoc
+// @Summary Get the schedule of a person
+// @Description Get the contracted weekly hours, work days and working hours of a person
+// @Tags People
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {object} service.Schedule "Schedule"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Person not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /people/{id}/schedule [get]
+func (c *PeopleController) Schedule(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("PeopleCntrl - Schedule - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, err := c.svc.GetSchedule(ctx, uri.ID)
+	if err != nil {
+		l.Error("PeopleCntrl - Schedule - GetSchedule error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+type updateScheduleReq struct {
+	WeeklyHours float64  `json:"weekly_hours" binding:"min=0"`
+	WorkDays    []string `json:"work_days" binding:"required"`
+	WorkStart   string   `json:"work_start" binding:"required"`
+	WorkEnd     string   `json:"work_end" binding:"required"`
+}
+
+// UpdateSchedule godoc
+// @Summary Set the schedule of a person
+// @Description Replace the contracted schedule of a person. Work days are mon, tue, wed, thu, fri, sat and sun,
+// @Description working hours are formatted as HH:MM and the weekly hours are spread evenly over the work days.
+// @Tags People
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param schedule body updateScheduleReq true "Schedule"
+// @Success 200 "Success"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Person not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /people/{id}/schedule [put]
+func (c *PeopleController) UpdateSchedule(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("PeopleCntrl - UpdateSchedule - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateScheduleReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("PeopleCntrl - UpdateSchedule - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Updating schedule of person", zap.Int32("id", uri.ID))
+
+	err := c.svc.UpdateSchedule(ctx, service.Schedule{
+		UserID:        uri.ID,
+		WeeklySeconds: hoursToSeconds(req.WeeklyHours),
+		WorkDays:      req.WorkDays,
+		WorkStart:     req.WorkStart,
+		WorkEnd:       req.WorkEnd,
+	})
+	if err != nil {
+		l.Error("PeopleCntrl - UpdateSchedule - UpdateSchedule error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrNoResult):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, service.ErrInvalidSchedule), errors.Is(err, service.ErrInvalidWorkDay), errors.Is(err, service.ErrInvalidClock):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Schedule updated successfully", zap.Int32("id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
+
+// Enrich godoc
+// @Summary Look a person up again
+// @Description Queue a new lookup of the person in the people info API, e.g. after enrichment failed.
+// @Description The person is pending until the lookup is done.
+// @Tags People
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 202 "Accepted"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Person not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /people/{id}/enrich [post]
+func (c *PeopleController) Enrich(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("PeopleCntrl - Enrich - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.RetryEnrichment(ctx, uri.ID)
+	if err != nil {
+		l.Error("PeopleCntrl - Enrich - RetryEnrichment error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Person enrichment queued", zap.Int32("id", uri.ID))
+	ctx.Status(http.StatusAccepted)
+}
@@ -0,0 +1,469 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type ProjectsController struct {
+	svc service.ProjectsService
+}
+
+func NewProjectsController(svc service.ProjectsService) *ProjectsController {
+	return &ProjectsController{
+		svc: svc,
+	}
+}
+
+type createClientReq struct {
+	Name    string `json:"name" binding:"required"`
+	Address string `json:"address"`
+}
+
+// CreateClient godoc
+// @Summary Create a client
+// @Description Create a client that projects can be billed to
+// @Tags Clients
+// @Accept  json
+// @Produce  json
+// @Param   client  body  createClientReq  true  "Client details"
+// @Success 200 {integer} int "Client ID"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /clients [post]
+func (c *ProjectsController) CreateClient(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req createClientReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("ProjectsController - CreateClient - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := c.svc.CreateClient(ctx, req.Name, req.Address)
+	if err != nil {
+		l.Error("ProjectsController - CreateClient - CreateClient error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Client created successfully", zap.Int32("client_id", id))
+	ctx.JSON(http.StatusOK, id)
+}
+
+type listClientsReq struct {
+	Name string `form:"name"`
+}
+
+// ListClients godoc
+// @Summary List clients
+// @Description List clients ordered by name, optionally filtered by a part of the name
+// @Tags Clients
+// @Produce  json
+// @Param name query string false "Name"
+// @Success 200 {array} service.Client "List of clients"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /clients [get]
+func (c *ProjectsController) ListClients(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req listClientsReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ProjectsController - ListClients - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	clients, err := c.svc.ListClients(ctx, req.Name)
+	if err != nil {
+		l.Error("ProjectsController - ListClients - ListClients error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Clients listed successfully", zap.Int("count", len(clients)))
+	ctx.JSON(http.StatusOK, clients)
+}
+
+// GetClient godoc
+// @Summary Get a client
+// @Description Get a client by its ID
+// @Tags Clients
+// @Produce  json
+// @Param   id  path  int  true  "Client ID"
+// @Success 200 {object} service.Client "Client"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Client not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /clients/{id} [get]
+func (c *ProjectsController) GetClient(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ProjectsController - GetClient - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	client, err := c.svc.GetClient(ctx, uri.ID)
+	if err != nil {
+		l.Error("ProjectsController - GetClient - GetClient error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Client fetched successfully", zap.Int32("client_id", uri.ID))
+	ctx.JSON(http.StatusOK, client)
+}
+
+type updateClientReq struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// UpdateClient godoc
+// @Summary Update a client
+// @Description Update a client's details; empty fields are kept
+// @Tags Clients
+// @Accept  json
+// @Produce  json
+// @Param   id  path  int  true  "Client ID"
+// @Param   client  body  updateClientReq  true  "Client details"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /clients/{id} [put]
+func (c *ProjectsController) UpdateClient(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ProjectsController - UpdateClient - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateClientReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("ProjectsController - UpdateClient - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.UpdateClient(ctx, service.Client{
+		ID:      uri.ID,
+		Name:    req.Name,
+		Address: req.Address,
+	})
+	if err != nil {
+		l.Error("ProjectsController - UpdateClient - UpdateClient error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Client updated successfully", zap.Int32("client_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
+
+// DeleteClient godoc
+// @Summary Delete a client
+// @Description Delete a client by its ID; its projects are kept without a client
+// @Tags Clients
+// @Produce  json
+// @Param   id  path  int  true  "Client ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Client not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /clients/{id} [delete]
+func (c *ProjectsController) DeleteClient(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ProjectsController - DeleteClient - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.DeleteClient(ctx, uri.ID)
+	if err != nil {
+		l.Error("ProjectsController - DeleteClient - DeleteClient error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Client deleted successfully", zap.Int32("client_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
+
+type createProjectReq struct {
+	Name        string  `json:"name" binding:"required"`
+	ClientID    int32   `json:"client_id" binding:"omitempty,min=1"`
+	BudgetHours float64 `json:"budget_hours" binding:"omitempty,min=0"`
+}
+
+// CreateProject godoc
+// @Summary Create a project
+// @Description Create a project for a client, or an internal one when client_id is omitted.
+// @Description budget_hours sets the time budget of the project.
+// @Tags Projects
+// @Accept  json
+// @Produce  json
+// @Param   project  body  createProjectReq  true  "Project details"
+// @Success 200 {integer} int "Project ID"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /projects [post]
+func (c *ProjectsController) CreateProject(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req createProjectReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("ProjectsController - CreateProject - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := c.svc.CreateProject(ctx, req.ClientID, req.Name, hoursToSeconds(req.BudgetHours))
+	if err != nil {
+		l.Error("ProjectsController - CreateProject - CreateProject error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) || errors.Is(err, service.ErrInvalidBudget) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Project created successfully", zap.Int32("project_id", id))
+	ctx.JSON(http.StatusOK, id)
+}
+
+type listProjectsReq struct {
+	ClientID int32  `form:"client_id" binding:"omitempty,min=1"`
+	Name     string `form:"name"`
+}
+
+// ListProjects godoc
+// @Summary List projects
+// @Description List projects ordered by name, optionally filtered by client and a part of the name
+// @Tags Projects
+// @Produce  json
+// @Param client_id query int false "Client ID"
+// @Param name query string false "Name"
+// @Success 200 {array} service.Project "List of projects"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /projects [get]
+func (c *ProjectsController) ListProjects(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req listProjectsReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ProjectsController - ListProjects - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	projects, err := c.svc.ListProjects(ctx, req.ClientID, req.Name)
+	if err != nil {
+		l.Error("ProjectsController - ListProjects - ListProjects error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Projects listed successfully", zap.Int("count", len(projects)))
+	ctx.JSON(http.StatusOK, projects)
+}
+
+// GetProject godoc
+// @Summary Get a project
+// @Description Get a project by its ID
+// @Tags Projects
+// @Produce  json
+// @Param   id  path  int  true  "Project ID"
+// @Success 200 {object} service.Project "Project"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /projects/{id} [get]
+func (c *ProjectsController) GetProject(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ProjectsController - GetProject - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	project, err := c.svc.GetProject(ctx, uri.ID)
+	if err != nil {
+		l.Error("ProjectsController - GetProject - GetProject error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Project fetched successfully", zap.Int32("project_id", uri.ID))
+	ctx.JSON(http.StatusOK, project)
+}
+
+type updateProjectReq struct {
+	Name        *string  `json:"name"`
+	ClientID    *int32   `json:"client_id" binding:"omitempty,min=0"`
+	BudgetHours *float64 `json:"budget_hours" binding:"omitempty,min=0"`
+}
+
+// UpdateProject godoc
+// @Summary Update a project
+// @Description Rename a project, move it to another client or change its budget; omitted fields are kept,
+// @Description client_id 0 detaches it from its client and budget_hours 0 removes the budget
+// @Tags Projects
+// @Accept  json
+// @Produce  json
+// @Param   id  path  int  true  "Project ID"
+// @Param   project  body  updateProjectReq  true  "Project details"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /projects/{id} [put]
+func (c *ProjectsController) UpdateProject(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ProjectsController - UpdateProject - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateProjectReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("ProjectsController - UpdateProject - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	project := service.UpdatedProject{
+		ID:       uri.ID,
+		ClientID: req.ClientID,
+		Name:     req.Name,
+	}
+	if req.BudgetHours != nil {
+		budget := hoursToSeconds(*req.BudgetHours)
+		project.BudgetSeconds = &budget
+	}
+
+	err := c.svc.UpdateProject(ctx, project)
+	if err != nil {
+		l.Error("ProjectsController - UpdateProject - UpdateProject error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) || errors.Is(err, service.ErrInvalidBudget) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Project updated successfully", zap.Int32("project_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
+
+// DeleteProject godoc
+// @Summary Delete a project
+// @Description Delete a project by its ID; its tasks are kept without a project
+// @Tags Projects
+// @Produce  json
+// @Param   id  path  int  true  "Project ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /projects/{id} [delete]
+func (c *ProjectsController) DeleteProject(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ProjectsController - DeleteProject - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.DeleteProject(ctx, uri.ID)
+	if err != nil {
+		l.Error("ProjectsController - DeleteProject - DeleteProject error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Project deleted successfully", zap.Int32("project_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
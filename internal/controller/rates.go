@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type RatesController struct {
+	svc service.RatesService
+}
+
+func NewRatesController(svc service.RatesService) *RatesController {
+	return &RatesController{
+		svc: svc,
+	}
+}
+
+type createRateReq struct {
+	UserID        int32  `json:"user_id" binding:"omitempty,min=1"`
+	ProjectID     int32  `json:"project_id" binding:"omitempty,min=1"`
+	TaskID        int32  `json:"task_id" binding:"omitempty,min=1"`
+	Amount        string `json:"amount" binding:"required"`
+	EffectiveFrom string `json:"effective_from"`
+}
+
+// Create godoc
+// @Summary Create an hourly rate
+// @Description Set the hourly rate of exactly one of a person, a project or a task from effective_from on
+// @Description (now when omitted). Rates keep their history: a later rate replaces an earlier one from its effective date.
+// @Description A work session is billed at the rate in effect when it started, taken from its task, else its project,
+// @Description else its person; non-billable tasks are not billed.
+// @Tags Rates
+// @Accept  json
+// @Produce  json
+// @Param   rate  body  createRateReq  true  "Rate, amount as a decimal string like 45.50 and effective_from as 2006-01-02 15:04:05"
+// @Success 200 {integer} int "Rate ID"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /rates [post]
+func (c *RatesController) Create(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req createRateReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("RatesController - Create - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rate := service.Rate{
+		UserID:    req.UserID,
+		ProjectID: req.ProjectID,
+		TaskID:    req.TaskID,
+		Amount:    req.Amount,
+	}
+	if req.EffectiveFrom != "" {
+		t, err := parseTime(req.EffectiveFrom)
+		if err != nil {
+			l.Error("RatesController - Create - time parsing error for effective_from", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		rate.EffectiveFrom = t
+	}
+
+	id, err := c.svc.CreateRate(ctx, rate)
+	if err != nil {
+		l.Error("RatesController - Create - CreateRate error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrNoResult),
+			errors.Is(err, service.ErrInvalidAmount),
+			errors.Is(err, service.ErrInvalidRateTarget):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Rate created successfully", zap.Int32("rate_id", id))
+	ctx.JSON(http.StatusOK, id)
+}
+
+type listRatesReq struct {
+	UserID    int32 `form:"user_id" binding:"omitempty,min=1"`
+	ProjectID int32 `form:"project_id" binding:"omitempty,min=1"`
+	TaskID    int32 `form:"task_id" binding:"omitempty,min=1"`
+}
+
+// List godoc
+// @Summary List hourly rates
+// @Description List the rate history, latest effective date first, optionally filtered by person, project or task
+// @Tags Rates
+// @Produce  json
+// @Param user_id query int false "Person ID"
+// @Param project_id query int false "Project ID"
+// @Param task_id query int false "Task ID"
+// @Success 200 {array} service.Rate "List of rates"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /rates [get]
+func (c *RatesController) List(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req listRatesReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("RatesController - List - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rates, err := c.svc.ListRates(ctx, req.UserID, req.ProjectID, req.TaskID)
+	if err != nil {
+		l.Error("RatesController - List - ListRates error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Rates listed successfully", zap.Int("count", len(rates)))
+	ctx.JSON(http.StatusOK, rates)
+}
+
+// Delete godoc
+// @Summary Delete an hourly rate
+// @Description Delete a rate entered by mistake; the previous rate applies again
+// @Tags Rates
+// @Produce  json
+// @Param   id  path  int  true  "Rate ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Rate not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /rates/{id} [delete]
+func (c *RatesController) Delete(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("RatesController - Delete - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.DeleteRate(ctx, uri.ID)
+	if err != nil {
+		l.Error("RatesController - Delete - DeleteRate error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Rate deleted successfully", zap.Int32("rate_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
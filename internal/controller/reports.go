@@ -0,0 +1,501 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type ReportsController struct {
+	svc service.ReportsService
+}
+
+func NewReportsController(svc service.ReportsService) *ReportsController {
+	return &ReportsController{
+		svc: svc,
+	}
+}
+
+type personReportReq struct {
+	FromDT string `form:"from_dt" binding:"required"`
+	ToDT   string `form:"to_dt" binding:"required"`
+	Period string `form:"period" binding:"omitempty,oneof=day week month"`
+	Clip   bool   `form:"clip"`
+}
+
+// Person godoc
+// @Summary Get a person's time report
+// @Description Get the time tracked by a person grouped into day, week or month buckets, with a per-task breakdown.
+// @Description Empty buckets are included with zero totals.
+// @Tags Reports
+// @Produce  json
+// @Param   id  path  int  true  "Person ID"
+// @Param   from_dt  query  string  true  "Range start, 2006-01-02 15:04:05"
+// @Param   to_dt  query  string  true  "Range end, 2006-01-02 15:04:05"
+// @Param   period  query  string  false  "Bucket size: day (default), week or month"
+// @Param   clip  query  bool  false  "Count running sessions up to now"
+// @Success 200 {object} service.PersonReport "Report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Person not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /reports/people/{id} [get]
+func (c *ReportsController) Person(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri personUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ReportsController - Person - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req personReportReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ReportsController - Person - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Period == "" {
+		req.Period = service.PeriodDay
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("ReportsController - Person - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("ReportsController - Person - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Building person report", zap.Int("user_id", uri.UserID), zap.String("period", req.Period), zap.String("from_dt", req.FromDT), zap.String("to_dt", req.ToDT))
+
+	report, err := c.svc.GetPersonReport(ctx, uri.UserID, req.Period, from, to, req.Clip)
+	if err != nil {
+		l.Error("ReportsController - Person - GetPersonReport error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrNoResult):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, service.ErrInvalidPeriod), errors.Is(err, service.ErrInvalidRange):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Person report built successfully", zap.Int("user_id", uri.UserID), zap.Int("bucket_count", len(report.Buckets)))
+	ctx.JSON(http.StatusOK, report)
+}
+
+type teamReportReq struct {
+	FromDT         string `form:"from_dt" binding:"required"`
+	ToDT           string `form:"to_dt" binding:"required"`
+	SortBy         string `form:"sort_by" binding:"omitempty,oneof=total_seconds task_count longest_task_seconds average_task_seconds surname name"`
+	Order          string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Clip           bool   `form:"clip"`
+	Limit          *int32 `form:"limit" binding:"omitempty,min=1"`
+	Page           *int32 `form:"page" binding:"omitempty,min=1"`
+	PassportSerie  *int32 `form:"passport_serie" binding:"omitempty,min=1"`
+	PassportNumber *int32 `form:"passport_number" binding:"omitempty,min=1"`
+	Surname        string `form:"surname"`
+	Name           string `form:"name"`
+	Patronymic     string `form:"patronymic"`
+	Address        string `form:"address"`
+}
+
+// Team godoc
+// @Summary Get the team workload report
+// @Description Get every person's total tracked time, task count, longest and average task length over a date range.
+// @Description People can be filtered like in /people/list; results are sorted by total time, descending, unless specified.
+// @Tags Reports
+// @Produce  json
+// @Param from_dt query string true "Range start, 2006-01-02 15:04:05"
+// @Param to_dt query string true "Range end, 2006-01-02 15:04:05"
+// @Param sort_by query string false "total_seconds, task_count, longest_task_seconds, average_task_seconds, surname or name"
+// @Param order query string false "asc or desc"
+// @Param limit query int false "Limit"
+// @Param page query int false "Page"
+// @Param passport_serie query int false "Passport Serie"
+// @Param passport_number query int false "Passport Number"
+// @Param surname query string false "Surname"
+// @Param name query string false "Name"
+// @Param patronymic query string false "Patronymic"
+// @Param address query string false "Address"
+// @Param clip query bool false "Count running sessions up to now"
+// @Success 200 {array} service.PersonWorkload "Workload per person"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /reports/team [get]
+func (c *ReportsController) Team(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req teamReportReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ReportsController - Team - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("ReportsController - Team - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("ReportsController - Team - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Building team report", zap.Any("filters", req))
+
+	workload, err := c.svc.GetTeamReport(ctx, service.Filter{
+		Limit:          req.Limit,
+		Offset:         req.Page,
+		PassportSerie:  req.PassportSerie,
+		PassportNumber: req.PassportNumber,
+		Surname:        req.Surname,
+		Name:           req.Name,
+		Patronymic:     req.Patronymic,
+		Address:        req.Address,
+	}, req.SortBy, req.Order != "asc", from, to, req.Clip)
+	if err != nil {
+		l.Error("ReportsController - Team - GetTeamReport error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidRange) || errors.Is(err, service.ErrInvalidSort) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Team report built successfully", zap.Int("count", len(workload)))
+	ctx.JSON(http.StatusOK, workload)
+}
+
+type projectReportReq struct {
+	FromDT    string `form:"from_dt" binding:"required"`
+	ToDT      string `form:"to_dt" binding:"required"`
+	ClientID  int32  `form:"client_id" binding:"omitempty,min=1"`
+	ProjectID int32  `form:"project_id" binding:"omitempty,min=1"`
+	Clip      bool   `form:"clip"`
+}
+
+// Projects godoc
+// @Summary Get the time report per client and project
+// @Description Get the time tracked over a date range grouped by client and project.
+// @Description Tasks without a project and projects without a client are reported under ID 0.
+// @Tags Reports
+// @Produce  json
+// @Param from_dt query string true "Range start, 2006-01-02 15:04:05"
+// @Param to_dt query string true "Range end, 2006-01-02 15:04:05"
+// @Param client_id query int false "Client ID"
+// @Param project_id query int false "Project ID"
+// @Param clip query bool false "Count running sessions up to now"
+// @Success 200 {array} service.ClientReport "Time per client and project"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /reports/projects [get]
+func (c *ReportsController) Projects(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req projectReportReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ReportsController - Projects - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("ReportsController - Projects - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("ReportsController - Projects - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Building project report", zap.Any("filters", req))
+
+	report, err := c.svc.GetProjectReport(ctx, req.ClientID, req.ProjectID, from, to, req.Clip)
+	if err != nil {
+		l.Error("ReportsController - Projects - GetProjectReport error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidRange) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Project report built successfully", zap.Int("client_count", len(report)))
+	ctx.JSON(http.StatusOK, report)
+}
+
+type tagReportReq struct {
+	FromDT string `form:"from_dt" binding:"required"`
+	ToDT   string `form:"to_dt" binding:"required"`
+	UserID int32  `form:"user_id" binding:"omitempty,min=1"`
+	Clip   bool   `form:"clip"`
+}
+
+// Tags godoc
+// @Summary Get the time report per person and tag
+// @Description Get the time tracked over a date range per person and tag. A task with several tags
+// @Description counts towards each of them; untagged tasks are left out.
+// @Tags Reports
+// @Produce  json
+// @Param from_dt query string true "Range start, 2006-01-02 15:04:05"
+// @Param to_dt query string true "Range end, 2006-01-02 15:04:05"
+// @Param user_id query int false "Person ID"
+// @Param clip query bool false "Count running sessions up to now"
+// @Success 200 {array} service.TagReport "Time per person and tag"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /reports/tags [get]
+func (c *ReportsController) Tags(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req tagReportReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ReportsController - Tags - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("ReportsController - Tags - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("ReportsController - Tags - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Building tag report", zap.Any("filters", req))
+
+	report, err := c.svc.GetTagReport(ctx, req.UserID, from, to, req.Clip)
+	if err != nil {
+		l.Error("ReportsController - Tags - GetTagReport error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidRange) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Tag report built successfully", zap.Int("person_count", len(report)))
+	ctx.JSON(http.StatusOK, report)
+}
+
+type burndownReq struct {
+	FromDT string `form:"from_dt" binding:"required"`
+	ToDT   string `form:"to_dt" binding:"required"`
+	Period string `form:"period" binding:"omitempty,oneof=day week month"`
+	Clip   bool   `form:"clip"`
+}
+
+// Burndown godoc
+// @Summary Get the budget burn-down of a project
+// @Description Get the budget of a project drawn down by the time tracked on it per day, week or month.
+// @Description Time tracked before from_dt counts as already spent; remaining time goes negative once the budget is overrun.
+// @Tags Reports
+// @Produce  json
+// @Param   id  path  int  true  "Project ID"
+// @Param   from_dt  query  string  true  "Range start, 2006-01-02 15:04:05"
+// @Param   to_dt  query  string  true  "Range end, 2006-01-02 15:04:05"
+// @Param   period  query  string  false  "Bucket size: day (default), week or month"
+// @Param   clip  query  bool  false  "Count running sessions up to now"
+// @Success 200 {object} service.ProjectBurndown "Burn-down"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "Project has no budget"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /reports/projects/{id}/burndown [get]
+func (c *ReportsController) Burndown(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ReportsController - Burndown - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req burndownReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ReportsController - Burndown - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Period == "" {
+		req.Period = service.PeriodDay
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("ReportsController - Burndown - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("ReportsController - Burndown - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Building project burn-down", zap.Int32("project_id", uri.ID), zap.String("period", req.Period), zap.String("from_dt", req.FromDT), zap.String("to_dt", req.ToDT))
+
+	burndown, err := c.svc.GetProjectBurndown(ctx, uri.ID, req.Period, from, to, req.Clip)
+	if err != nil {
+		l.Error("ReportsController - Burndown - GetProjectBurndown error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrNoResult):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, service.ErrNoBudget):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, service.ErrInvalidPeriod), errors.Is(err, service.ErrInvalidRange):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Project burn-down built successfully", zap.Int32("project_id", uri.ID), zap.Int("point_count", len(burndown.Points)))
+	ctx.JSON(http.StatusOK, burndown)
+}
+
+type overtimeReq struct {
+	FromDT       string `form:"from_dt" binding:"required"`
+	ToDT         string `form:"to_dt" binding:"required"`
+	Period       string `form:"period" binding:"omitempty,oneof=day week month"`
+	NightStart   string `form:"night_start"`
+	NightEnd     string `form:"night_end"`
+	Basis        string `form:"basis" binding:"omitempty,oneof=day week"`
+	OutsideHours bool   `form:"outside_hours"`
+	Clip         bool   `form:"clip"`
+}
+
+// Overtime godoc
+// @Summary Get the overtime report of a person
+// @Description Compare the time tracked by a person with their schedule per day, week or month.
+// @Description Time on days off counts as weekend time and time in the night hours of a work day as night time.
+// @Description The rest is regular time up to the contracted hours of the day (basis=day) or week (basis=week)
+// @Description and overtime beyond that; with outside_hours, time outside the working hours is always overtime.
+// @Tags Reports
+// @Produce  json
+// @Param   id  path  int  true  "Person ID"
+// @Param   from_dt  query  string  true  "Range start, 2006-01-02 15:04:05"
+// @Param   to_dt  query  string  true  "Range end, 2006-01-02 15:04:05"
+// @Param   period  query  string  false  "Bucket size: day (default), week or month"
+// @Param   night_start  query  string  false  "Start of the night hours, HH:MM (default 22:00)"
+// @Param   night_end  query  string  false  "End of the night hours, HH:MM (default 06:00)"
+// @Param   basis  query  string  false  "Overtime basis: day (default) or week"
+// @Param   outside_hours  query  bool  false  "Count time outside the working hours as overtime"
+// @Param   clip  query  bool  false  "Count running sessions up to now"
+// @Success 200 {object} service.OvertimeReport "Overtime report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Person not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /reports/people/{id}/overtime [get]
+func (c *ReportsController) Overtime(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("ReportsController - Overtime - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req overtimeReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("ReportsController - Overtime - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Period == "" {
+		req.Period = service.PeriodDay
+	}
+
+	from, err := parseTime(req.FromDT)
+	if err != nil {
+		l.Error("ReportsController - Overtime - time parsing error for from_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	to, err := parseTime(req.ToDT)
+	if err != nil {
+		l.Error("ReportsController - Overtime - time parsing error for to_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Building overtime report", zap.Int32("user_id", uri.ID), zap.String("period", req.Period), zap.String("from_dt", req.FromDT), zap.String("to_dt", req.ToDT))
+
+	report, err := c.svc.GetOvertimeReport(ctx, uri.ID, req.Period, from, to, service.OvertimeRules{
+		NightStart:   req.NightStart,
+		NightEnd:     req.NightEnd,
+		Basis:        req.Basis,
+		OutsideHours: req.OutsideHours,
+	}, req.Clip)
+	if err != nil {
+		l.Error("ReportsController - Overtime - GetOvertimeReport error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrNoResult):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, service.ErrInvalidPeriod), errors.Is(err, service.ErrInvalidRange),
+			errors.Is(err, service.ErrInvalidClock), errors.Is(err, service.ErrInvalidBasis):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Overtime report built successfully", zap.Int32("user_id", uri.ID), zap.Int("bucket_count", len(report.Buckets)))
+	ctx.JSON(http.StatusOK, report)
+}
@@ -2,10 +2,12 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/ical"
 	"github.com/gogoalish/timetracker/internal/logger"
 	"github.com/gogoalish/timetracker/internal/service"
 	"go.uber.org/zap"
@@ -24,17 +26,19 @@ func NewTasksController(svc service.TasksService) *TasksController {
 type createTaskReq struct {
 	Description string `json:"description" binding:"required"`
 	UserID      int    `json:"user_id" binding:"required,min=1"`
+	ProjectID   int32  `json:"project_id" binding:"omitempty,min=1"`
 }
 
 // Create godoc
 // @Summary Create a new task
-// @Description Create a new task with a specific user ID and description
+// @Description Create a new task with a specific user ID and description, optionally attached to a project
 // @Tags Tasks
 // @Accept  json
 // @Produce  json
-// @Param   task  body  createTaskReq  true  "Task description and user ID"
+// @Param   task  body  createTaskReq  true  "Task description, user ID and project ID"
 // @Success 200 {integer} int "Task ID"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /tasks/create [post]
 func (c *TasksController) Create(ctx *gin.Context) {
@@ -51,9 +55,17 @@ func (c *TasksController) Create(ctx *gin.Context) {
 		return
 	}
 
-	id, err := c.svc.CreateTask(ctx, req.UserID, req.Description)
+	id, err := c.svc.CreateTask(ctx, req.UserID, req.Description, req.ProjectID)
 	if err != nil {
 		l.Error("TasksController - Create - CreateTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -63,18 +75,22 @@ func (c *TasksController) Create(ctx *gin.Context) {
 }
 
 type taskStartReq struct {
-	ID int `json:"id" binding:"required,min=1"`
+	ID         int  `json:"id" binding:"required,min=1"`
+	SwitchOver bool `json:"switch_over"`
 }
 
 // Start godoc
 // @Summary Start a task
-// @Description Start a task by its ID
+// @Description Start a task by its ID. A person can only have one running task;
+// @Description with switch_over set, the currently running task is paused instead of failing with 409.
 // @Tags Tasks
 // @Accept  json
 // @Produce  json
 // @Param   task  body  taskStartReq  true  "Task ID"
 // @Success 200
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /tasks/start [put]
 func (c *TasksController) Start(ctx *gin.Context) {
@@ -93,13 +109,21 @@ func (c *TasksController) Start(ctx *gin.Context) {
 
 	l.Debug("Starting task", zap.Int("task_id", req.ID))
 
-	err := c.svc.StartTask(ctx, req.ID)
+	err := c.svc.StartTask(ctx, req.ID, req.SwitchOver)
 	if err != nil {
 		l.Error("TasksController - Start - StartTask error", zap.Error(err))
 		if errors.Is(err, service.ErrNoResult) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(err))
 			return
 		}
+		if errors.Is(err, service.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -121,6 +145,8 @@ type taskEndReq struct {
 // @Param   task  body  taskEndReq  true  "Task ID"
 // @Success 200
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /tasks/end [post]
 func (c *TasksController) End(ctx *gin.Context) {
@@ -146,6 +172,14 @@ func (c *TasksController) End(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, errorResponse(err))
 			return
 		}
+		if errors.Is(err, service.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -154,21 +188,197 @@ func (c *TasksController) End(ctx *gin.Context) {
 	ctx.Status(http.StatusOK)
 }
 
+type taskPauseReq struct {
+	ID int `json:"id" binding:"required,min=1"`
+}
+
+// Pause godoc
+// @Summary Pause a task
+// @Description Pause a running task by its ID, closing its current work session
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param   task  body  taskPauseReq  true  "Task ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/pause [post]
+func (c *TasksController) Pause(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req taskPauseReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TasksController - Pause - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Pausing task", zap.Int("task_id", req.ID))
+
+	err := c.svc.PauseTask(ctx, req.ID)
+	if err != nil {
+		l.Error("TasksController - Pause - PauseTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Task paused successfully", zap.Int("task_id", req.ID))
+	ctx.Status(http.StatusOK)
+}
+
+type taskResumeReq struct {
+	ID         int  `json:"id" binding:"required,min=1"`
+	SwitchOver bool `json:"switch_over"`
+}
+
+// Resume godoc
+// @Summary Resume a task
+// @Description Resume a paused task by its ID, opening a new work session.
+// @Description With switch_over set, the currently running task of the person is paused instead of failing with 409.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param   task  body  taskResumeReq  true  "Task ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/resume [post]
+func (c *TasksController) Resume(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req taskResumeReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TasksController - Resume - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Resuming task", zap.Int("task_id", req.ID))
+
+	err := c.svc.ResumeTask(ctx, req.ID, req.SwitchOver)
+	if err != nil {
+		l.Error("TasksController - Resume - ResumeTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Task resumed successfully", zap.Int("task_id", req.ID))
+	ctx.Status(http.StatusOK)
+}
+
+type taskCancelReq struct {
+	ID int `json:"id" binding:"required,min=1"`
+}
+
+// Cancel godoc
+// @Summary Cancel a task
+// @Description Cancel an unfinished task by its ID
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param   task  body  taskCancelReq  true  "Task ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/cancel [post]
+func (c *TasksController) Cancel(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req taskCancelReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TasksController - Cancel - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Cancelling task", zap.Int("task_id", req.ID))
+
+	err := c.svc.CancelTask(ctx, req.ID)
+	if err != nil {
+		l.Error("TasksController - Cancel - CancelTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Task cancelled successfully", zap.Int("task_id", req.ID))
+	ctx.Status(http.StatusOK)
+}
+
 type getOrderedTasksReq struct {
 	UserID int    `json:"user_id" binding:"required,min=1"`
 	FromDT string `json:"from_dt" binding:"required"`
 	ToDT   string `json:"to_dt" binding:"required"`
+	Tag    string `json:"tag"`
 }
 
-const dateLayout = "2006-01-02 15:04:05"
+type orderedTasksQuery struct {
+	Clip bool `form:"clip"`
+}
 
 // Ordered godoc
 // @Summary Get ordered tasks
-// @Description Get ordered tasks by user ID and date range
+// @Description Get ordered tasks by user ID and date range. By default only tasks started and finished within the range are returned.
+// @Description With clip set, every task with time tracked in the range is returned, with its time clipped to the range
+// @Description and running sessions counted up to now. A tag keeps only the tasks carrying it.
 // @Tags Tasks
 // @Accept  json
 // @Produce  json
-// @Param   tasks  body  getOrderedTasksReq  true  "User ID and date range"
+// @Param   tasks  body  getOrderedTasksReq  true  "User ID, date range and optional tag"
+// @Param   clip  query  bool  false  "Include tasks overlapping the range, clipped to it"
 // @Success 200 {array} service.Task "List of tasks"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -186,25 +396,35 @@ func (c *TasksController) Ordered(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	var query orderedTasksQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		l.Error("TasksController - Ordered - query binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
-	from, err := time.Parse(dateLayout, req.FromDT)
+	from, err := parseTime(req.FromDT)
 	if err != nil {
 		l.Error("TasksController - Ordered - time parsing error for from_dt", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	to, err := time.Parse(dateLayout, req.ToDT)
+	to, err := parseTime(req.ToDT)
 	if err != nil {
 		l.Error("TasksController - Ordered - time parsing error for to_dt", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	l.Debug("Fetching ordered tasks", zap.Int("user_id", req.UserID), zap.String("from_dt", req.FromDT), zap.String("to_dt", req.ToDT))
+	l.Debug("Fetching ordered tasks", zap.Int("user_id", req.UserID), zap.String("from_dt", req.FromDT), zap.String("to_dt", req.ToDT), zap.Bool("clip", query.Clip), zap.String("tag", req.Tag))
 
-	tasks, err := c.svc.GetOrderedTasks(ctx, req.UserID, from, to)
+	tasks, err := c.svc.GetOrderedTasks(ctx, req.UserID, from, to, query.Clip, req.Tag)
 	if err != nil {
 		l.Error("TasksController - Ordered - GetOrderedTasks error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidRange) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -212,3 +432,675 @@ func (c *TasksController) Ordered(ctx *gin.Context) {
 	l.Info("Ordered tasks fetched successfully", zap.Int("user_id", req.UserID), zap.Int("task_count", len(tasks)))
 	ctx.JSON(http.StatusOK, tasks)
 }
+
+// Current godoc
+// @Summary Get the running task of a person
+// @Description Get the currently running task of a person with the time elapsed on it so far
+// @Tags Tasks
+// @Produce  json
+// @Param   id  path  int  true  "Person ID"
+// @Success 200 {object} service.CurrentTask "Running task"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "No running task"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /people/{id}/current-task [get]
+func (c *TasksController) Current(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req personUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		l.Error("TasksController - Current - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Fetching current task", zap.Int("user_id", req.UserID))
+
+	task, err := c.svc.GetCurrentTask(ctx, req.UserID)
+	if err != nil {
+		l.Error("TasksController - Current - GetCurrentTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Current task fetched successfully", zap.Int("user_id", req.UserID), zap.Int32("task_id", task.ID))
+	ctx.JSON(http.StatusOK, task)
+}
+
+type calendarReq struct {
+	FromDT string `form:"from_dt"`
+	ToDT   string `form:"to_dt"`
+}
+
+// defaultCalendarWindow is how far back the calendar feed goes when from_dt is not set.
+const defaultCalendarWindow = 90 * 24 * time.Hour
+
+// Calendar godoc
+// @Summary Get a person's tasks as an iCalendar feed
+// @Description Get every work session of a person's tasks as VEVENTs, with the task description as summary.
+// @Description By default the feed covers the last 90 days; running sessions end at the time of the request.
+// @Tags Tasks
+// @Produce  text/calendar
+// @Param   id  path  int  true  "Person ID"
+// @Param   from_dt  query  string  false  "Window start, 2006-01-02 15:04:05"
+// @Param   to_dt  query  string  false  "Window end, 2006-01-02 15:04:05"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /people/{id}/calendar.ics [get]
+func (c *TasksController) Calendar(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri personUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TasksController - Calendar - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req calendarReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("TasksController - Calendar - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	to := time.Now().Add(24 * time.Hour)
+	if req.ToDT != "" {
+		t, err := parseTime(req.ToDT)
+		if err != nil {
+			l.Error("TasksController - Calendar - time parsing error for to_dt", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		to = t
+	}
+	from := to.Add(-defaultCalendarWindow)
+	if req.FromDT != "" {
+		t, err := parseTime(req.FromDT)
+		if err != nil {
+			l.Error("TasksController - Calendar - time parsing error for from_dt", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		from = t
+	}
+
+	l.Debug("Building calendar", zap.Int("user_id", uri.UserID), zap.Time("from_dt", from), zap.Time("to_dt", to))
+
+	events, err := c.svc.GetCalendar(ctx, uri.UserID, from, to)
+	if err != nil {
+		l.Error("TasksController - Calendar - GetCalendar error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidRange) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Type", "text/calendar; charset=utf-8")
+	ctx.Status(http.StatusOK)
+	if err := ical.Write(ctx.Writer, fmt.Sprintf("Tasks of person %d", uri.UserID), events); err != nil {
+		l.Error("TasksController - Calendar - write error", zap.Error(err))
+		return
+	}
+
+	l.Info("Calendar built successfully", zap.Int("user_id", uri.UserID), zap.Int("event_count", len(events)))
+}
+
+type taskUri struct {
+	ID int `uri:"id" binding:"required,min=1"`
+}
+
+type taskEditReq struct {
+	Description   *string  `json:"description"`
+	ProjectID     *int32   `json:"project_id" binding:"omitempty,min=0"`
+	Billable      *bool    `json:"billable"`
+	EstimateHours *float64 `json:"estimate_hours" binding:"omitempty,min=0"`
+	StartDT       *string  `json:"start_dt"`
+	EndDT         *string  `json:"end_dt"`
+	ChangedBy     string   `json:"changed_by" binding:"required"`
+}
+
+// Update godoc
+// @Summary Edit a task
+// @Description Change the description, project, billable flag, estimate and/or the start and end times of a task; omitted fields are kept,
+// @Description project_id 0 detaches the task from its project and estimate_hours 0 removes the estimate.
+// @Description New times replace the work sessions of the task with a single one and finish it.
+// @Description The change is recorded in the task audit log under changed_by.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param   id  path  int  true  "Task ID"
+// @Param   task  body  taskEditReq  true  "New values, times as 2006-01-02 15:04:05"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Task is running or overlaps another task"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/{id} [put]
+func (c *TasksController) Update(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri taskUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TasksController - Update - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req taskEditReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TasksController - Update - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	edit := service.TaskEdit{
+		ID:          int32(uri.ID),
+		Description: req.Description,
+		ProjectID:   req.ProjectID,
+		Billable:    req.Billable,
+		ChangedBy:   req.ChangedBy,
+	}
+	if req.EstimateHours != nil {
+		estimate := hoursToSeconds(*req.EstimateHours)
+		edit.EstimateSeconds = &estimate
+	}
+	if req.StartDT != nil {
+		t, err := parseTime(*req.StartDT)
+		if err != nil {
+			l.Error("TasksController - Update - time parsing error for start_dt", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		edit.StartDt = &t
+	}
+	if req.EndDT != nil {
+		t, err := parseTime(*req.EndDT)
+		if err != nil {
+			l.Error("TasksController - Update - time parsing error for end_dt", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		edit.EndDt = &t
+	}
+
+	l.Debug("Editing task", zap.Int("task_id", uri.ID), zap.String("changed_by", req.ChangedBy))
+
+	err := c.svc.EditTask(ctx, edit)
+	if err != nil {
+		l.Error("TasksController - Update - EditTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) || errors.Is(err, service.ErrInvalidInterval) || errors.Is(err, service.ErrEndInFuture) ||
+			errors.Is(err, service.ErrInvalidEstimate) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrInvalidTransition) || errors.Is(err, service.ErrOverlap) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Task edited successfully", zap.Int("task_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
+
+type manualTaskReq struct {
+	UserID      int    `json:"user_id" binding:"required,min=1"`
+	ProjectID   int32  `json:"project_id" binding:"omitempty,min=1"`
+	Description string `json:"description" binding:"required"`
+	StartDT     string `json:"start_dt" binding:"required"`
+	EndDT       string `json:"end_dt" binding:"required"`
+	ChangedBy   string `json:"changed_by" binding:"required"`
+}
+
+// Manual godoc
+// @Summary Create a finished task with explicit times
+// @Description Record work that was done without starting the timer. The interval must end in the past
+// @Description and must not overlap another task of the person. The entry is recorded in the task audit log under changed_by.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param   task  body  manualTaskReq  true  "Task, times as 2006-01-02 15:04:05"
+// @Success 200 {integer} int "Task ID"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Overlaps another task"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/manual [post]
+func (c *TasksController) Manual(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req manualTaskReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TasksController - Manual - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	start, err := parseTime(req.StartDT)
+	if err != nil {
+		l.Error("TasksController - Manual - time parsing error for start_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	end, err := parseTime(req.EndDT)
+	if err != nil {
+		l.Error("TasksController - Manual - time parsing error for end_dt", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Creating manual task", zap.Int("user_id", req.UserID), zap.String("changed_by", req.ChangedBy))
+
+	id, err := c.svc.CreateManualTask(ctx, service.ManualTask{
+		UserID:      int32(req.UserID),
+		ProjectID:   req.ProjectID,
+		Description: req.Description,
+		StartDt:     start,
+		EndDt:       end,
+		ChangedBy:   req.ChangedBy,
+	})
+	if err != nil {
+		l.Error("TasksController - Manual - CreateManualTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) || errors.Is(err, service.ErrInvalidInterval) || errors.Is(err, service.ErrEndInFuture) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrOverlap) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Manual task created successfully", zap.Int("task_id", int(id)))
+	ctx.JSON(http.StatusOK, id)
+}
+
+// Get godoc
+// @Summary Get a task
+// @Description Get a task by its ID with the time tracked on it so far
+// @Tags Tasks
+// @Produce  json
+// @Param   id  path  int  true  "Task ID"
+// @Success 200 {object} service.Task "Task"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Task not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/{id} [get]
+func (c *TasksController) Get(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri taskUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TasksController - Get - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Fetching task", zap.Int("task_id", uri.ID))
+
+	task, err := c.svc.GetTask(ctx, uri.ID)
+	if err != nil {
+		l.Error("TasksController - Get - GetTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Task fetched successfully", zap.Int("task_id", uri.ID))
+	ctx.JSON(http.StatusOK, task)
+}
+
+type listTasksReq struct {
+	Limit       *int32 `form:"limit" binding:"omitempty,min=1"`
+	Page        *int32 `form:"page" binding:"omitempty,min=1"`
+	UserID      int32  `form:"user_id" binding:"omitempty,min=1"`
+	ProjectID   int32  `form:"project_id" binding:"omitempty,min=1"`
+	Status      string `form:"status"`
+	Query       string `form:"q"`
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	Running     bool   `form:"running"`
+	Tag         string `form:"tag"`
+}
+
+// List godoc
+// @Summary List tasks
+// @Description List tasks of every status, newest first, with optional filters.
+// @Description q is matched against the description with full-text search.
+// @Tags Tasks
+// @Produce  json
+// @Param limit query int false "Limit"
+// @Param page query int false "Page"
+// @Param user_id query int false "Person ID"
+// @Param project_id query int false "Project ID"
+// @Param status query string false "Status" Enums(created, running, paused, done, cancelled)
+// @Param q query string false "Description search"
+// @Param created_from query string false "Created at or after, 2006-01-02 15:04:05"
+// @Param created_to query string false "Created before, 2006-01-02 15:04:05"
+// @Param running query bool false "Only running tasks"
+// @Param tag query string false "Tag"
+// @Success 200 {array} service.Task "List of tasks"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks [get]
+func (c *TasksController) List(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req listTasksReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("TasksController - List - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	filter := service.TaskFilter{
+		Limit:       req.Limit,
+		Offset:      req.Page,
+		UserID:      req.UserID,
+		ProjectID:   req.ProjectID,
+		Status:      req.Status,
+		Query:       req.Query,
+		RunningOnly: req.Running,
+		Tag:         req.Tag,
+	}
+	if req.CreatedFrom != "" {
+		t, err := parseTime(req.CreatedFrom)
+		if err != nil {
+			l.Error("TasksController - List - time parsing error for created_from", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		filter.CreatedFrom = &t
+	}
+	if req.CreatedTo != "" {
+		t, err := parseTime(req.CreatedTo)
+		if err != nil {
+			l.Error("TasksController - List - time parsing error for created_to", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		filter.CreatedTo = &t
+	}
+
+	l.Debug("Listing tasks with filters", zap.Any("filters", req))
+
+	tasks, err := c.svc.ListTasks(ctx, filter)
+	if err != nil {
+		l.Error("TasksController - List - ListTasks error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidStatus) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Tasks listed successfully", zap.Int("count", len(tasks)))
+	ctx.JSON(http.StatusOK, tasks)
+}
+
+type overEstimateReq struct {
+	UserID    int32 `form:"user_id" binding:"omitempty,min=1"`
+	ProjectID int32 `form:"project_id" binding:"omitempty,min=1"`
+}
+
+// OverEstimate godoc
+// @Summary List tasks over estimate
+// @Description List estimated tasks whose tracked time, counting running sessions up to now, exceeds the estimate,
+// @Description the largest overrun first, optionally filtered by person and project.
+// @Tags Tasks
+// @Produce  json
+// @Param user_id query int false "Person ID"
+// @Param project_id query int false "Project ID"
+// @Success 200 {array} service.Task "List of tasks"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/over-estimate [get]
+func (c *TasksController) OverEstimate(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req overEstimateReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("TasksController - OverEstimate - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	tasks, err := c.svc.ListOverEstimate(ctx, req.UserID, req.ProjectID)
+	if err != nil {
+		l.Error("TasksController - OverEstimate - ListOverEstimate error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Tasks over estimate listed successfully", zap.Int("count", len(tasks)))
+	ctx.JSON(http.StatusOK, tasks)
+}
+
+// Delete godoc
+// @Summary Delete a task
+// @Description Delete a task by its ID. The task is hidden from listings and reports but kept in the database;
+// @Description a running task is paused first.
+// @Tags Tasks
+// @Produce  json
+// @Param   id  path  int  true  "Task ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Task not found"
+// @Failure 423 {object} map[string]interface{} "Time falls into a locked period"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/{id} [delete]
+func (c *TasksController) Delete(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri taskUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TasksController - Delete - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Deleting task", zap.Int("task_id", uri.ID))
+
+	err := c.svc.DeleteTask(ctx, uri.ID)
+	if err != nil {
+		l.Error("TasksController - Delete - DeleteTask error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, service.ErrPeriodLocked) {
+			ctx.JSON(http.StatusLocked, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Task deleted successfully", zap.Int("task_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
+
+type addTagReq struct {
+	Tag string `json:"tag" binding:"required"`
+}
+
+type taskTagUri struct {
+	ID  int    `uri:"id" binding:"required,min=1"`
+	Tag string `uri:"tag" binding:"required"`
+}
+
+// AddTag godoc
+// @Summary Tag a task
+// @Description Attach a tag to a task. Tags are case-insensitive and created on first use; attaching a tag twice has no effect.
+// @Tags Tasks
+// @Accept  json
+// @Produce  json
+// @Param   id  path  int  true  "Task ID"
+// @Param   tag  body  addTagReq  true  "Tag"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Task not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/{id}/tags [post]
+func (c *TasksController) AddTag(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri taskUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TasksController - AddTag - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req addTagReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TasksController - AddTag - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Tagging task", zap.Int("task_id", uri.ID), zap.String("tag", req.Tag))
+
+	err := c.svc.AddTag(ctx, uri.ID, req.Tag)
+	if err != nil {
+		l.Error("TasksController - AddTag - AddTag error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrNoResult):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, service.ErrEmptyTag):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Task tagged successfully", zap.Int("task_id", uri.ID), zap.String("tag", req.Tag))
+	ctx.Status(http.StatusOK)
+}
+
+// RemoveTag godoc
+// @Summary Untag a task
+// @Description Detach a tag from a task
+// @Tags Tasks
+// @Produce  json
+// @Param   id  path  int  true  "Task ID"
+// @Param   tag  path  string  true  "Tag"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Task or tag not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tasks/{id}/tags/{tag} [delete]
+func (c *TasksController) RemoveTag(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri taskTagUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TasksController - RemoveTag - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	l.Debug("Untagging task", zap.Int("task_id", uri.ID), zap.String("tag", uri.Tag))
+
+	err := c.svc.RemoveTag(ctx, uri.ID, uri.Tag)
+	if err != nil {
+		l.Error("TasksController - RemoveTag - RemoveTag error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Task untagged successfully", zap.Int("task_id", uri.ID), zap.String("tag", uri.Tag))
+	ctx.Status(http.StatusOK)
+}
+
+// ListTags godoc
+// @Summary List tags
+// @Description List every tag in use, ordered by name
+// @Tags Tasks
+// @Produce  json
+// @Success 200 {array} string "List of tags"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tags [get]
+func (c *TasksController) ListTags(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	tags, err := c.svc.ListTags(ctx)
+	if err != nil {
+		l.Error("TasksController - ListTags - ListTags error", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Tags fetched successfully", zap.Int("tag_count", len(tags)))
+	ctx.JSON(http.StatusOK, tags)
+}
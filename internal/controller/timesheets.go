@@ -0,0 +1,308 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/service"
+	"go.uber.org/zap"
+)
+
+type TimesheetsController struct {
+	svc service.TimesheetsService
+}
+
+func NewTimesheetsController(svc service.TimesheetsService) *TimesheetsController {
+	return &TimesheetsController{
+		svc: svc,
+	}
+}
+
+type createTimesheetReq struct {
+	UserID int32  `json:"user_id" binding:"required,min=1"`
+	Week   string `json:"week" binding:"required"`
+}
+
+// Create godoc
+// @Summary Create a timesheet
+// @Description Open a draft timesheet of a person for the week, Monday to Monday, containing the given time
+// @Tags Timesheets
+// @Accept  json
+// @Produce  json
+// @Param   timesheet  body  createTimesheetReq  true  "Person and any time within the week, as 2006-01-02 15:04:05"
+// @Success 200 {integer} int "Timesheet ID"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Timesheet already exists"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /timesheets [post]
+func (c *TimesheetsController) Create(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req createTimesheetReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TimesheetsController - Create - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	week, err := parseTime(req.Week)
+	if err != nil {
+		l.Error("TimesheetsController - Create - time parsing error for week", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	id, err := c.svc.CreateTimesheet(ctx, req.UserID, week)
+	if err != nil {
+		l.Error("TimesheetsController - Create - CreateTimesheet error", zap.Error(err))
+		switch {
+		case errors.Is(err, service.ErrNoResult):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, service.ErrTimesheetExists):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	l.Info("Timesheet created successfully", zap.Int32("timesheet_id", id))
+	ctx.JSON(http.StatusOK, id)
+}
+
+type listTimesheetsReq struct {
+	UserID int32  `form:"user_id" binding:"omitempty,min=1"`
+	Status string `form:"status"`
+}
+
+// List godoc
+// @Summary List timesheets
+// @Description List timesheets, latest week first, optionally filtered by person and status
+// @Tags Timesheets
+// @Produce  json
+// @Param user_id query int false "Person ID"
+// @Param status query string false "Status" Enums(draft, submitted, approved, rejected)
+// @Success 200 {array} service.Timesheet "List of timesheets"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /timesheets [get]
+func (c *TimesheetsController) List(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var req listTimesheetsReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		l.Error("TimesheetsController - List - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timesheets, err := c.svc.ListTimesheets(ctx, req.UserID, req.Status)
+	if err != nil {
+		l.Error("TimesheetsController - List - ListTimesheets error", zap.Error(err))
+		if errors.Is(err, service.ErrInvalidTimesheetStatus) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Timesheets listed successfully", zap.Int("count", len(timesheets)))
+	ctx.JSON(http.StatusOK, timesheets)
+}
+
+// Get godoc
+// @Summary Get a timesheet
+// @Description Get a timesheet with the person's tasks of the week, their time clipped to the week
+// @Tags Timesheets
+// @Produce  json
+// @Param   id  path  int  true  "Timesheet ID"
+// @Success 200 {object} service.Timesheet "Timesheet"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Timesheet not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /timesheets/{id} [get]
+func (c *TimesheetsController) Get(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TimesheetsController - Get - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timesheet, err := c.svc.GetTimesheet(ctx, uri.ID)
+	if err != nil {
+		l.Error("TimesheetsController - Get - GetTimesheet error", zap.Error(err))
+		if errors.Is(err, service.ErrNoResult) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	l.Info("Timesheet fetched successfully", zap.Int32("timesheet_id", uri.ID))
+	ctx.JSON(http.StatusOK, timesheet)
+}
+
+// Submit godoc
+// @Summary Submit a timesheet
+// @Description Submit a draft or rejected timesheet for review
+// @Tags Timesheets
+// @Produce  json
+// @Param   id  path  int  true  "Timesheet ID"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Timesheet not found"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /timesheets/{id}/submit [post]
+func (c *TimesheetsController) Submit(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TimesheetsController - Submit - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.SubmitTimesheet(ctx, uri.ID)
+	if err != nil {
+		l.Error("TimesheetsController - Submit - SubmitTimesheet error", zap.Error(err))
+		timesheetTransitionError(ctx, err)
+		return
+	}
+
+	l.Info("Timesheet submitted successfully", zap.Int32("timesheet_id", uri.ID))
+	ctx.Status(http.StatusOK)
+}
+
+type reviewTimesheetReq struct {
+	ReviewedBy string `json:"reviewed_by" binding:"required"`
+	Comment    string `json:"comment"`
+}
+
+// Approve godoc
+// @Summary Approve a timesheet
+// @Description Approve a submitted timesheet. Tasks can no longer be started, stopped, edited or deleted
+// @Description within the approved week.
+// @Tags Timesheets
+// @Accept  json
+// @Produce  json
+// @Param   id  path  int  true  "Timesheet ID"
+// @Param   review  body  reviewTimesheetReq  true  "Reviewer and an optional comment"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Timesheet not found"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /timesheets/{id}/approve [post]
+func (c *TimesheetsController) Approve(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TimesheetsController - Approve - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req reviewTimesheetReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TimesheetsController - Approve - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.ApproveTimesheet(ctx, uri.ID, req.ReviewedBy, req.Comment)
+	if err != nil {
+		l.Error("TimesheetsController - Approve - ApproveTimesheet error", zap.Error(err))
+		timesheetTransitionError(ctx, err)
+		return
+	}
+
+	l.Info("Timesheet approved successfully", zap.Int32("timesheet_id", uri.ID), zap.String("reviewed_by", req.ReviewedBy))
+	ctx.Status(http.StatusOK)
+}
+
+// Reject godoc
+// @Summary Reject a timesheet
+// @Description Send a submitted timesheet back to the person with a comment saying why; it can be submitted again
+// @Tags Timesheets
+// @Accept  json
+// @Produce  json
+// @Param   id  path  int  true  "Timesheet ID"
+// @Param   review  body  reviewTimesheetReq  true  "Reviewer and a required comment"
+// @Success 200
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Timesheet not found"
+// @Failure 409 {object} map[string]interface{} "Illegal status transition"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /timesheets/{id}/reject [post]
+func (c *TimesheetsController) Reject(ctx *gin.Context) {
+	l, ok := logger.FromContext(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ErrNoLogger))
+		return
+	}
+
+	var uri idUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		l.Error("TimesheetsController - Reject - uri binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req reviewTimesheetReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		l.Error("TimesheetsController - Reject - binding error", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := c.svc.RejectTimesheet(ctx, uri.ID, req.ReviewedBy, req.Comment)
+	if err != nil {
+		l.Error("TimesheetsController - Reject - RejectTimesheet error", zap.Error(err))
+		timesheetTransitionError(ctx, err)
+		return
+	}
+
+	l.Info("Timesheet rejected successfully", zap.Int32("timesheet_id", uri.ID), zap.String("reviewed_by", req.ReviewedBy))
+	ctx.Status(http.StatusOK)
+}
+
+// timesheetTransitionError writes the response for an error of a timesheet status change.
+func timesheetTransitionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNoResult):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, service.ErrEmptyComment):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	case errors.Is(err, service.ErrInvalidTimesheetTransition):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
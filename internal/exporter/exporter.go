@@ -0,0 +1,136 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// DateLayout is used to render timestamps in text formats.
+const DateLayout = "2006-01-02 15:04:05"
+
+var ErrUnknownFormat = errors.New("unknown export format")
+var ErrUnknownColumn = errors.New("unknown export column")
+
+// Writer streams rows of cells to the underlying io.Writer. Cells may be strings,
+// integers, floats, time.Time or nil. Close must be called to flush the output.
+type Writer interface {
+	Write(row []any) error
+	Close() error
+}
+
+// New returns a Writer for the given format.
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Column describes how to render one field of T.
+type Column[T any] struct {
+	Key    string
+	Header string
+	Value  func(T) any
+}
+
+// Table is an ordered set of columns written to a Writer.
+type Table[T any] struct {
+	columns []Column[T]
+	w       Writer
+}
+
+// NewTable picks the columns named in keys, in that order, from all.
+// An empty keys selects every column.
+func NewTable[T any](w Writer, all []Column[T], keys []string) (*Table[T], error) {
+	if len(keys) == 0 {
+		return &Table[T]{columns: all, w: w}, nil
+	}
+
+	columns := make([]Column[T], 0, len(keys))
+	for _, key := range keys {
+		found := false
+		for _, c := range all {
+			if c.Key == key {
+				columns = append(columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, key)
+		}
+	}
+	return &Table[T]{columns: columns, w: w}, nil
+}
+
+func (t *Table[T]) WriteHeader() error {
+	row := make([]any, len(t.columns))
+	for i, c := range t.columns {
+		row[i] = c.Header
+	}
+	return t.w.Write(row)
+}
+
+func (t *Table[T]) WriteRow(v T) error {
+	row := make([]any, len(t.columns))
+	for i, c := range t.columns {
+		row[i] = c.Value(v)
+	}
+	return t.w.Write(row)
+}
+
+// ParseColumns splits a comma separated list of column keys.
+func ParseColumns(s string) []string {
+	var keys []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func formatCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(DateLayout)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
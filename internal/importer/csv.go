@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parseCSV reads a CSV file with a header row. Recognized columns are description,
+// start, end, passport_serie, passport_number, surname, name, patronymic and person
+// (full name); the person is taken from the passport columns when they are filled.
+func parseCSV(r io.Reader) ([]Entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading csv header: %v", ErrMalformed, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"start", "end"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: csv header has no %q column", ErrMalformed, required)
+		}
+	}
+
+	var entries []Entry
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		e := Entry{Row: line}
+		if err != nil {
+			var perr *csv.ParseError
+			if !errors.As(err, &perr) {
+				return nil, err
+			}
+			e.Err = err
+			entries = append(entries, e)
+			continue
+		}
+
+		get := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		e.Description = get("description")
+		e.FullName = get("person")
+		if e.FullName == "" {
+			e.FullName = strings.Join(strings.Fields(get("surname")+" "+get("name")+" "+get("patronymic")), " ")
+		}
+		if serie, number := get("passport_serie"), get("passport_number"); serie != "" || number != "" {
+			s, serr := strconv.ParseInt(serie, 10, 32)
+			n, nerr := strconv.ParseInt(number, 10, 32)
+			if serr != nil || nerr != nil {
+				e.Err = fmt.Errorf("invalid passport %q %q", serie, number)
+				entries = append(entries, e)
+				continue
+			}
+			e.PassportSerie, e.PassportNumber = int32(s), int32(n)
+		}
+
+		if e.Start, err = parseTime(get("start")); err != nil {
+			e.Err = fmt.Errorf("start: %w", err)
+		} else if e.End, err = parseTime(get("end")); err != nil {
+			e.Err = fmt.Errorf("end: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
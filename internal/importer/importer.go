@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV      = "csv"
+	FormatToggl    = "toggl"
+	FormatClockify = "clockify"
+)
+
+var ErrUnknownFormat = errors.New("unknown import format")
+var ErrMalformed = errors.New("malformed import file")
+
+// Entry is one time entry read from an export. Person is identified either by
+// passport (PassportSerie and PassportNumber both set) or by FullName.
+type Entry struct {
+	// Row is the 1-based position of the entry in the source: the line for CSV,
+	// the index in the entries array for JSON formats.
+	Row            int
+	PassportSerie  int32
+	PassportNumber int32
+	FullName       string
+	Description    string
+	Start          time.Time
+	End            time.Time
+	// Err is set when the entry could not be read; the other fields may be incomplete.
+	Err error
+}
+
+// Parse reads every entry of an export in the given format. Malformed entries are
+// returned with Err set so they can be reported, only an unreadable source fails as a whole.
+func Parse(format string, r io.Reader) ([]Entry, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatToggl:
+		return parseToggl(r)
+	case FormatClockify:
+		return parseClockify(r)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+}
+
+// parseTime accepts the layouts above. Times carrying a zone are converted to local
+// time, since tasks store wall clock timestamps like time.Now() produces.
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if layout == time.RFC3339Nano {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.In(time.Local), nil
+			}
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", s)
+}
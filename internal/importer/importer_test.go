@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func local(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []Entry
+		wantErr error
+		// wantRowErr are the rows read with Err set
+		wantRowErr []int
+	}{
+		{
+			name:   "csv with passport and names",
+			format: FormatCSV,
+			input: "Description,Start,End,passport_serie,passport_number,surname,name\n" +
+				"Code review,2024-03-01 09:00:00,2024-03-01 10:30:00,1234,567890,,\n" +
+				"Standup,2024-03-01 10:30,2024-03-01 10:45,,,Иванов,Иван\n",
+			want: []Entry{
+				{Row: 2, PassportSerie: 1234, PassportNumber: 567890, Description: "Code review", Start: local("2024-03-01 09:00:00"), End: local("2024-03-01 10:30:00")},
+				{Row: 3, FullName: "Иванов Иван", Description: "Standup", Start: local("2024-03-01 10:30:00"), End: local("2024-03-01 10:45:00")},
+			},
+		},
+		{
+			name:   "csv person column wins over name columns",
+			format: FormatCSV,
+			input: "person,surname,name,start,end\n" +
+				"Ivan Ivanov,Петров,Пётр,2024-03-01T09:00:00,2024-03-01T10:00:00\n",
+			want: []Entry{
+				{Row: 2, FullName: "Ivan Ivanov", Start: local("2024-03-01 09:00:00"), End: local("2024-03-01 10:00:00")},
+			},
+		},
+		{
+			name:   "csv bad rows are reported, not fatal",
+			format: FormatCSV,
+			input: "person,start,end,passport_serie,passport_number\n" +
+				"Ivan Ivanov,yesterday,2024-03-01 10:00:00,,\n" +
+				"Ivan Ivanov,2024-03-01 09:00:00,2024-03-01 10:00:00,12a4,567890\n" +
+				"Ivan Ivanov,2024-03-01 09:00:00,2024-03-01 10:00:00,,\n",
+			wantRowErr: []int{2, 3},
+		},
+		{
+			name:    "csv without end column",
+			format:  FormatCSV,
+			input:   "person,start\nIvan Ivanov,2024-03-01 09:00:00\n",
+			wantErr: ErrMalformed,
+		},
+		{
+			name:   "toggl entries array",
+			format: FormatToggl,
+			input:  `[{"description":"Design","start":"2024-03-01T09:00:00Z","stop":"2024-03-01T11:00:00Z","user_name":"Ivan Ivanov"}]`,
+			want: []Entry{
+				{Row: 1, FullName: "Ivan Ivanov", Description: "Design", Start: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC).Local(), End: time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC).Local()},
+			},
+		},
+		{
+			name:   "toggl detailed report",
+			format: FormatToggl,
+			input:  `{"data":[{"description":"Design","start":"2024-03-01T09:00:00+03:00","end":"2024-03-01T10:00:00+03:00","user":"Ivan Ivanov"},{"description":"Running","start":"2024-03-01T10:00:00+03:00"}]}`,
+			want: []Entry{
+				{Row: 1, FullName: "Ivan Ivanov", Description: "Design", Start: time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC).Local(), End: time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC).Local()},
+			},
+			wantRowErr: []int{2},
+		},
+		{
+			name:   "clockify detailed report",
+			format: FormatClockify,
+			input:  `{"timeentries":[{"description":"Support","userName":"Ivan Ivanov","timeInterval":{"start":"2024-03-01T09:00:00Z","end":"2024-03-01T09:30:00Z"}}]}`,
+			want: []Entry{
+				{Row: 1, FullName: "Ivan Ivanov", Description: "Support", Start: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC).Local(), End: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC).Local()},
+			},
+		},
+		{
+			name:    "clockify object without entries",
+			format:  FormatClockify,
+			input:   `{"entries":[]}`,
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "broken json",
+			format:  FormatToggl,
+			input:   `[{"description":`,
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "unknown format",
+			format:  "harvest",
+			input:   "",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Parse(tt.format, strings.NewReader(tt.input))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var good []Entry
+			var rowErr []int
+			for _, e := range entries {
+				if e.Err != nil {
+					rowErr = append(rowErr, e.Row)
+					continue
+				}
+				good = append(good, e)
+			}
+			if len(rowErr) != len(tt.wantRowErr) {
+				t.Fatalf("rows with errors = %v, want %v", rowErr, tt.wantRowErr)
+			}
+			for i := range rowErr {
+				if rowErr[i] != tt.wantRowErr[i] {
+					t.Fatalf("rows with errors = %v, want %v", rowErr, tt.wantRowErr)
+				}
+			}
+			if tt.want == nil {
+				return
+			}
+			if len(good) != len(tt.want) {
+				t.Fatalf("Parse() read %d entries, want %d: %+v", len(good), len(tt.want), good)
+			}
+			for i, want := range tt.want {
+				got := good[i]
+				if got.Row != want.Row || got.PassportSerie != want.PassportSerie || got.PassportNumber != want.PassportNumber ||
+					got.FullName != want.FullName || got.Description != want.Description ||
+					!got.Start.Equal(want.Start) || !got.End.Equal(want.End) {
+					t.Errorf("entry %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2024-03-01T09:00:00Z", want: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+		{input: "2024-03-01T09:00:00.5+03:00", want: time.Date(2024, 3, 1, 6, 0, 0, 500_000_000, time.UTC)},
+		{input: "2024-03-01 09:00:00", want: local("2024-03-01 09:00:00")},
+		{input: " 2024-03-01T09:00:00 ", want: local("2024-03-01 09:00:00")},
+		{input: "2024-03-01 09:00", want: local("2024-03-01 09:00:00")},
+		{input: "01.03.2024 09:00", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseTime(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTime() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (!got.Equal(tt.want) || got.Location() != time.Local) {
+				t.Errorf("parseTime() = %v, want %v in local time", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// togglEntry covers both the time entries API (stop) and the detailed report export (end, user).
+type togglEntry struct {
+	Description string `json:"description"`
+	Start       string `json:"start"`
+	Stop        string `json:"stop"`
+	End         string `json:"end"`
+	User        string `json:"user"`
+	UserName    string `json:"user_name"`
+}
+
+// parseToggl reads a Toggl Track export: either a plain array of time entries
+// or a detailed report object holding them in "data".
+func parseToggl(r io.Reader) ([]Entry, error) {
+	var raw []togglEntry
+	if err := decodeEntries(r, "data", &raw); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(raw))
+	for i, t := range raw {
+		e := Entry{
+			Row:         i + 1,
+			Description: t.Description,
+			FullName:    t.User,
+		}
+		if e.FullName == "" {
+			e.FullName = t.UserName
+		}
+		end := t.End
+		if end == "" {
+			end = t.Stop
+		}
+		e.Start, e.End, e.Err = parseInterval(t.Start, end)
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+type clockifyEntry struct {
+	Description  string `json:"description"`
+	UserName     string `json:"userName"`
+	TimeInterval struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	} `json:"timeInterval"`
+}
+
+// parseClockify reads a Clockify export: either a plain array of time entries
+// or a detailed report object holding them in "timeentries".
+func parseClockify(r io.Reader) ([]Entry, error) {
+	var raw []clockifyEntry
+	if err := decodeEntries(r, "timeentries", &raw); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(raw))
+	for i, c := range raw {
+		e := Entry{
+			Row:         i + 1,
+			Description: c.Description,
+			FullName:    c.UserName,
+		}
+		e.Start, e.End, e.Err = parseInterval(c.TimeInterval.Start, c.TimeInterval.End)
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// decodeEntries decodes a JSON array into v, or the array stored under key
+// when the document is an object.
+func decodeEntries(r io.Reader, key string, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var wrapper map[string]json.RawMessage
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		inner, ok := wrapper[key]
+		if !ok {
+			return fmt.Errorf("%w: export has no %q field", ErrMalformed, key)
+		}
+		data = inner
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return nil
+}
+
+func parseInterval(start, end string) (s, e time.Time, err error) {
+	if end == "" {
+		return s, e, errors.New("entry is still running")
+	}
+	if s, err = parseTime(start); err != nil {
+		return s, e, fmt.Errorf("start: %w", err)
+	}
+	if e, err = parseTime(end); err != nil {
+		return s, e, fmt.Errorf("end: %w", err)
+	}
+	return s, e, nil
+}
@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type EnrichmentRepo interface {
+	ClaimEnrichmentJobs(ctx context.Context, arg ClaimEnrichmentJobsParams) ([]EnrichmentJob, error)
+	DeleteEnrichmentJob(ctx context.Context, id int32) error
+	RenewEnrichmentJobLease(ctx context.Context, arg RenewEnrichmentJobLeaseParams) (int64, error)
+	RescheduleEnrichmentJob(ctx context.Context, arg RescheduleEnrichmentJobParams) error
+	GetPersonByID(ctx context.Context, id int32) (Person, error)
+	EnrichPerson(ctx context.Context, arg EnrichPersonParams) error
+	SetPersonEnrichmentStatus(ctx context.Context, arg SetPersonEnrichmentStatusParams) error
+
+	// ExecTx runs fn against a repo bound to a single transaction,
+	// committing if fn returns nil and rolling back otherwise.
+	ExecTx(ctx context.Context, fn func(EnrichmentRepo) error) error
+}
+
+type enrichmentRepo struct {
+	*Queries
+	db *sql.DB
+}
+
+func NewEnrichmentRepo(db *sql.DB) EnrichmentRepo {
+	return &enrichmentRepo{
+		Queries: New(db),
+		db:      db,
+	}
+}
+
+func (r *enrichmentRepo) ExecTx(ctx context.Context, fn func(EnrichmentRepo) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(&enrichmentRepo{
+		Queries: r.Queries.WithTx(tx),
+		db:      r.db,
+	})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rollback err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ExportRepo reads rows one at a time instead of loading the whole result,
+// which sqlc generated :many queries always do.
+type ExportRepo interface {
+	StreamOrderedTasks(ctx context.Context, arg StreamOrderedTasksParams, fn func(ExportTaskRow) error) error
+}
+
+func NewExportRepo(db DBTX) ExportRepo {
+	return New(db)
+}
+
+const streamOrderedTasks = `
+SELECT t.id, t.user_id, p.surname, p.name, p.patronymic, t.description, t.status, t.start_dt, t.end_dt,
+    CAST(EXTRACT(EPOCH FROM SUM(te.end_dt - te.start_dt)) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN people p ON p.id = t.user_id
+JOIN time_entries te ON te.task_id = t.id
+WHERE ($1::int = 0 OR t.user_id = $1) AND
+t.deleted_at IS NULL AND
+t.start_dt >= $2 AND
+t.end_dt <= $3
+GROUP BY t.id, p.id
+ORDER BY p.surname, p.name, t.user_id, total_seconds DESC, t.id
+`
+
+// streamClippedTasks is the clipped mode of streamOrderedTasks: tasks overlapping the range
+// are included with their time clipped to it, and open sessions run until $4.
+const streamClippedTasks = `
+SELECT t.id, t.user_id, p.surname, p.name, p.patronymic, t.description, t.status, t.start_dt, t.end_dt,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(COALESCE(te.end_dt, $4::timestamp), $3::timestamp) - GREATEST(te.start_dt, $2::timestamp)
+    )) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN people p ON p.id = t.user_id
+JOIN time_entries te ON te.task_id = t.id
+WHERE ($1::int = 0 OR t.user_id = $1) AND
+t.deleted_at IS NULL AND
+t.status <> 'cancelled' AND
+te.start_dt < $3::timestamp AND
+COALESCE(te.end_dt, $4::timestamp) > $2::timestamp
+GROUP BY t.id, p.id
+ORDER BY p.surname, p.name, t.user_id, total_seconds DESC, t.id
+`
+
+type StreamOrderedTasksParams struct {
+	// UserID limits the export to one person, 0 exports everyone.
+	UserID  int32
+	StartDt time.Time
+	EndDt   time.Time
+	// Clip selects streamClippedTasks, counting running sessions up to Now.
+	Clip bool
+	Now  time.Time
+}
+
+type ExportTaskRow struct {
+	ID           int32
+	UserID       int32
+	Surname      string
+	Name         string
+	Patronymic   sql.NullString
+	Description  string
+	Status       TaskStatus
+	StartDt      sql.NullTime
+	EndDt        sql.NullTime
+	TotalSeconds int64
+}
+
+func (q *Queries) StreamOrderedTasks(ctx context.Context, arg StreamOrderedTasksParams, fn func(ExportTaskRow) error) error {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if arg.Clip {
+		rows, err = q.db.QueryContext(ctx, streamClippedTasks, arg.UserID, arg.StartDt, arg.EndDt, arg.Now)
+	} else {
+		rows, err = q.db.QueryContext(ctx, streamOrderedTasks, arg.UserID, arg.StartDt, arg.EndDt)
+	}
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ExportTaskRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Surname,
+			&i.Name,
+			&i.Patronymic,
+			&i.Description,
+			&i.Status,
+			&i.StartDt,
+			&i.EndDt,
+			&i.TotalSeconds,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
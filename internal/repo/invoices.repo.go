@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type InvoicesRepo interface {
+	GetBillableLines(ctx context.Context, arg GetBillableLinesParams) ([]GetBillableLinesRow, error)
+	CountOverlappingInvoices(ctx context.Context, arg CountOverlappingInvoicesParams) (int64, error)
+	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (int32, error)
+	CreateInvoiceLine(ctx context.Context, arg CreateInvoiceLineParams) error
+	GetInvoiceByID(ctx context.Context, id int32) (Invoice, error)
+	ListInvoices(ctx context.Context, clientID int32) ([]Invoice, error)
+	ListInvoiceLines(ctx context.Context, invoiceID int32) ([]InvoiceLine, error)
+	GetClientByIDForUpdate(ctx context.Context, id int32) (Client, error)
+
+	// ExecTx runs fn against a repo bound to a single transaction,
+	// committing if fn returns nil and rolling back otherwise.
+	ExecTx(ctx context.Context, fn func(InvoicesRepo) error) error
+}
+
+type invoicesRepo struct {
+	*Queries
+	db *sql.DB
+}
+
+func NewInvoicesRepo(db *sql.DB) InvoicesRepo {
+	return &invoicesRepo{
+		Queries: New(db),
+		db:      db,
+	}
+}
+
+func (r *invoicesRepo) ExecTx(ctx context.Context, fn func(InvoicesRepo) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(&invoicesRepo{
+		Queries: r.Queries.WithTx(tx),
+		db:      r.db,
+	})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rollback err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
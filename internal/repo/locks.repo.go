@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+)
+
+type LocksRepo interface {
+	CreatePeriodLock(ctx context.Context, arg CreatePeriodLockParams) (int32, error)
+	DeletePeriodLock(ctx context.Context, id int32) (int64, error)
+	ListPeriodLocks(ctx context.Context, userID int32) ([]PeriodLock, error)
+	GetPersonByID(ctx context.Context, id int32) (Person, error)
+}
+
+func NewLocksRepo(db DBTX) LocksRepo {
+	return New(db)
+}
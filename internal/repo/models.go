@@ -6,24 +6,289 @@ package repo
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"time"
 )
 
-type Person struct {
-	ID             int32          `json:"id"`
-	Name           string         `json:"name"`
-	Surname        string         `json:"surname"`
-	Patronymic     sql.NullString `json:"patronymic"`
-	PassportNumber int32          `json:"passport_number"`
-	PassportSerie  int32          `json:"passport_serie"`
-	Address        string         `json:"address"`
+type EnrichmentStatus string
+
+const (
+	EnrichmentStatusPending  EnrichmentStatus = "pending"
+	EnrichmentStatusEnriched EnrichmentStatus = "enriched"
+	EnrichmentStatusFailed   EnrichmentStatus = "failed"
+)
+
+func (e *EnrichmentStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnrichmentStatus(s)
+	case string:
+		*e = EnrichmentStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnrichmentStatus: %T", src)
+	}
+	return nil
 }
 
-type Task struct {
+type NullEnrichmentStatus struct {
+	EnrichmentStatus EnrichmentStatus `json:"enrichment_status"`
+	Valid            bool             `json:"valid"` // Valid is true if EnrichmentStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnrichmentStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnrichmentStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnrichmentStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnrichmentStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnrichmentStatus), nil
+}
+
+type TaskStatus string
+
+const (
+	TaskStatusCreated   TaskStatus = "created"
+	TaskStatusRunning   TaskStatus = "running"
+	TaskStatusPaused    TaskStatus = "paused"
+	TaskStatusDone      TaskStatus = "done"
+	TaskStatusCancelled TaskStatus = "cancelled"
+)
+
+func (e *TaskStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TaskStatus(s)
+	case string:
+		*e = TaskStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TaskStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTaskStatus struct {
+	TaskStatus TaskStatus `json:"task_status"`
+	Valid      bool       `json:"valid"` // Valid is true if TaskStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTaskStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TaskStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TaskStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTaskStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TaskStatus), nil
+}
+
+type TimesheetStatus string
+
+const (
+	TimesheetStatusDraft     TimesheetStatus = "draft"
+	TimesheetStatusSubmitted TimesheetStatus = "submitted"
+	TimesheetStatusApproved  TimesheetStatus = "approved"
+	TimesheetStatusRejected  TimesheetStatus = "rejected"
+)
+
+func (e *TimesheetStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TimesheetStatus(s)
+	case string:
+		*e = TimesheetStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TimesheetStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTimesheetStatus struct {
+	TimesheetStatus TimesheetStatus `json:"timesheet_status"`
+	Valid           bool            `json:"valid"` // Valid is true if TimesheetStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTimesheetStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TimesheetStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TimesheetStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTimesheetStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TimesheetStatus), nil
+}
+
+type Client struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EnrichmentJob struct {
 	ID          int32        `json:"id"`
-	UserID      int32        `json:"user_id"`
-	Description string       `json:"description"`
-	StartDt     sql.NullTime `json:"start_dt"`
-	EndDt       sql.NullTime `json:"end_dt"`
+	PersonID    int32        `json:"person_id"`
+	Attempts    int32        `json:"attempts"`
+	RunAt       time.Time    `json:"run_at"`
+	LockedUntil sql.NullTime `json:"locked_until"`
+	LastError   string       `json:"last_error"`
 	CreatedAt   time.Time    `json:"created_at"`
 }
+
+type Invoice struct {
+	ID            int32         `json:"id"`
+	Number        string        `json:"number"`
+	ClientID      sql.NullInt32 `json:"client_id"`
+	ClientName    string        `json:"client_name"`
+	ClientAddress string        `json:"client_address"`
+	FromDt        time.Time     `json:"from_dt"`
+	ToDt          time.Time     `json:"to_dt"`
+	TaxRate       string        `json:"tax_rate"`
+	Subtotal      string        `json:"subtotal"`
+	Tax           string        `json:"tax"`
+	Total         string        `json:"total"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type InvoiceLine struct {
+	ID          int32  `json:"id"`
+	InvoiceID   int32  `json:"invoice_id"`
+	ProjectID   int32  `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	UserID      int32  `json:"user_id"`
+	PersonName  string `json:"person_name"`
+	Rate        string `json:"rate"`
+	Seconds     int64  `json:"seconds"`
+	Amount      string `json:"amount"`
+}
+
+type PeopleInfoCache struct {
+	PassportSerie  int32     `json:"passport_serie"`
+	PassportNumber int32     `json:"passport_number"`
+	Result         string    `json:"result"`
+	Surname        string    `json:"surname"`
+	Name           string    `json:"name"`
+	Patronymic     string    `json:"patronymic"`
+	Address        string    `json:"address"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+type PeriodLock struct {
+	ID        int32         `json:"id"`
+	UserID    sql.NullInt32 `json:"user_id"`
+	FromDt    time.Time     `json:"from_dt"`
+	ToDt      time.Time     `json:"to_dt"`
+	LockedBy  string        `json:"locked_by"`
+	Reason    string        `json:"reason"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Person struct {
+	ID               int32            `json:"id"`
+	Name             string           `json:"name"`
+	Surname          string           `json:"surname"`
+	Patronymic       sql.NullString   `json:"patronymic"`
+	PassportNumber   int32            `json:"passport_number"`
+	PassportSerie    int32            `json:"passport_serie"`
+	Address          string           `json:"address"`
+	WeeklySeconds    int64            `json:"weekly_seconds"`
+	WorkDays         int32            `json:"work_days"`
+	WorkStartMinute  int32            `json:"work_start_minute"`
+	WorkEndMinute    int32            `json:"work_end_minute"`
+	EnrichmentStatus EnrichmentStatus `json:"enrichment_status"`
+	EnrichmentError  string           `json:"enrichment_error"`
+}
+
+type Project struct {
+	ID            int32         `json:"id"`
+	ClientID      sql.NullInt32 `json:"client_id"`
+	Name          string        `json:"name"`
+	CreatedAt     time.Time     `json:"created_at"`
+	BudgetSeconds sql.NullInt64 `json:"budget_seconds"`
+}
+
+type Rate struct {
+	ID            int32         `json:"id"`
+	UserID        sql.NullInt32 `json:"user_id"`
+	ProjectID     sql.NullInt32 `json:"project_id"`
+	TaskID        sql.NullInt32 `json:"task_id"`
+	Amount        string        `json:"amount"`
+	EffectiveFrom time.Time     `json:"effective_from"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type Tag struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+type Task struct {
+	ID              int32         `json:"id"`
+	UserID          int32         `json:"user_id"`
+	Description     string        `json:"description"`
+	StartDt         sql.NullTime  `json:"start_dt"`
+	EndDt           sql.NullTime  `json:"end_dt"`
+	CreatedAt       time.Time     `json:"created_at"`
+	Status          TaskStatus    `json:"status"`
+	DeletedAt       sql.NullTime  `json:"deleted_at"`
+	ProjectID       sql.NullInt32 `json:"project_id"`
+	Billable        bool          `json:"billable"`
+	EstimateSeconds sql.NullInt64 `json:"estimate_seconds"`
+}
+
+type TaskAudit struct {
+	ID        int32     `json:"id"`
+	TaskID    int32     `json:"task_id"`
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+	Note      string    `json:"note"`
+}
+
+type TaskTag struct {
+	TaskID int32 `json:"task_id"`
+	TagID  int32 `json:"tag_id"`
+}
+
+type TimeEntry struct {
+	ID      int32        `json:"id"`
+	TaskID  int32        `json:"task_id"`
+	StartDt time.Time    `json:"start_dt"`
+	EndDt   sql.NullTime `json:"end_dt"`
+}
+
+type Timesheet struct {
+	ID          int32           `json:"id"`
+	UserID      int32           `json:"user_id"`
+	WeekStart   time.Time       `json:"week_start"`
+	Status      TimesheetStatus `json:"status"`
+	Comment     string          `json:"comment"`
+	SubmittedAt sql.NullTime    `json:"submitted_at"`
+	ReviewedBy  string          `json:"reviewed_by"`
+	ReviewedAt  sql.NullTime    `json:"reviewed_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
@@ -2,18 +2,55 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 )
 
 type PeopleRepo interface {
-	CreatePerson(ctx context.Context, arg CreatePersonParams) (int32, error)
+	CreatePendingPerson(ctx context.Context, arg CreatePendingPersonParams) (int32, error)
 	DeletePerson(ctx context.Context, id int32) error
 	GetPersonByID(ctx context.Context, id int32) (Person, error)
 	GetPersonByPassport(ctx context.Context, arg GetPersonByPassportParams) (Person, error)
 	ListPeople(ctx context.Context, arg ListPeopleParams) ([]Person, error)
 	ListPeopleWithLimit(ctx context.Context, arg ListPeopleWithLimitParams) ([]Person, error)
 	UpdatePerson(ctx context.Context, arg UpdatePersonParams) error
+	UpdatePersonSchedule(ctx context.Context, arg UpdatePersonScheduleParams) error
+	SetPersonEnrichmentStatus(ctx context.Context, arg SetPersonEnrichmentStatusParams) error
+	UpsertEnrichmentJob(ctx context.Context, arg UpsertEnrichmentJobParams) error
+
+	// ExecTx runs fn against a repo bound to a single transaction,
+	// committing if fn returns nil and rolling back otherwise.
+	ExecTx(ctx context.Context, fn func(PeopleRepo) error) error
+}
+
+type peopleRepo struct {
+	*Queries
+	db *sql.DB
 }
 
-func NewPeopleRepo(db DBTX) PeopleRepo {
-	return New(db)
+func NewPeopleRepo(db *sql.DB) PeopleRepo {
+	return &peopleRepo{
+		Queries: New(db),
+		db:      db,
+	}
+}
+
+func (r *peopleRepo) ExecTx(ctx context.Context, fn func(PeopleRepo) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(&peopleRepo{
+		Queries: r.Queries.WithTx(tx),
+		db:      r.db,
+	})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rollback err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -10,6 +10,23 @@ import (
 	"database/sql"
 )
 
+const createPendingPerson = `-- name: CreatePendingPerson :one
+INSERT INTO people (name, surname, address, passport_number, passport_serie, enrichment_status) VALUES ('', '', '', $1, $2, 'pending')
+RETURNING id
+`
+
+type CreatePendingPersonParams struct {
+	PassportNumber int32 `json:"passport_number"`
+	PassportSerie  int32 `json:"passport_serie"`
+}
+
+func (q *Queries) CreatePendingPerson(ctx context.Context, arg CreatePendingPersonParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, createPendingPerson, arg.PassportNumber, arg.PassportSerie)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createPerson = `-- name: CreatePerson :one
 INSERT INTO people (name, surname, patronymic, address, passport_number, passport_serie) VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING id
@@ -47,8 +64,39 @@ func (q *Queries) DeletePerson(ctx context.Context, id int32) error {
 	return err
 }
 
+const enrichPerson = `-- name: EnrichPerson :exec
+UPDATE people
+SET
+    name = COALESCE(NULLIF(name, ''), $2),
+    surname = COALESCE(NULLIF(surname, ''), $3),
+    patronymic = COALESCE(NULLIF(patronymic, ''), $4),
+    address = COALESCE(NULLIF(address, ''), $5),
+    enrichment_status = 'enriched',
+    enrichment_error = ''
+WHERE id = $1
+`
+
+type EnrichPersonParams struct {
+	ID         int32          `json:"id"`
+	Name       string         `json:"name"`
+	Surname    string         `json:"surname"`
+	Patronymic sql.NullString `json:"patronymic"`
+	Address    string         `json:"address"`
+}
+
+func (q *Queries) EnrichPerson(ctx context.Context, arg EnrichPersonParams) error {
+	_, err := q.db.ExecContext(ctx, enrichPerson,
+		arg.ID,
+		arg.Name,
+		arg.Surname,
+		arg.Patronymic,
+		arg.Address,
+	)
+	return err
+}
+
 const getPersonByID = `-- name: GetPersonByID :one
-SELECT id, name, surname, patronymic, passport_number, passport_serie, address FROM people
+SELECT id, name, surname, patronymic, passport_number, passport_serie, address, weekly_seconds, work_days, work_start_minute, work_end_minute, enrichment_status, enrichment_error FROM people
 WHERE id = $1
 `
 
@@ -63,12 +111,18 @@ func (q *Queries) GetPersonByID(ctx context.Context, id int32) (Person, error) {
 		&i.PassportNumber,
 		&i.PassportSerie,
 		&i.Address,
+		&i.WeeklySeconds,
+		&i.WorkDays,
+		&i.WorkStartMinute,
+		&i.WorkEndMinute,
+		&i.EnrichmentStatus,
+		&i.EnrichmentError,
 	)
 	return i, err
 }
 
 const getPersonByPassport = `-- name: GetPersonByPassport :one
-SELECT id, name, surname, patronymic, passport_number, passport_serie, address FROM people
+SELECT id, name, surname, patronymic, passport_number, passport_serie, address, weekly_seconds, work_days, work_start_minute, work_end_minute, enrichment_status, enrichment_error FROM people
 WHERE passport_number = $1 AND passport_serie = $2
 `
 
@@ -88,29 +142,37 @@ func (q *Queries) GetPersonByPassport(ctx context.Context, arg GetPersonByPasspo
 		&i.PassportNumber,
 		&i.PassportSerie,
 		&i.Address,
+		&i.WeeklySeconds,
+		&i.WorkDays,
+		&i.WorkStartMinute,
+		&i.WorkEndMinute,
+		&i.EnrichmentStatus,
+		&i.EnrichmentError,
 	)
 	return i, err
 }
 
 const listPeople = `-- name: ListPeople :many
-SELECT id, name, surname, patronymic, passport_number, passport_serie, address FROM people
+SELECT id, name, surname, patronymic, passport_number, passport_serie, address, weekly_seconds, work_days, work_start_minute, work_end_minute, enrichment_status, enrichment_error FROM people
 WHERE
     ($1::int = 0 OR passport_serie = $1) AND
     ($2::int = 0 OR passport_number = $2) AND
     ($3::text = '' OR surname ILIKE '%' || $3 || '%') AND
     ($4::text = '' OR name ILIKE '%' || $4 || '%') AND
     ($5::text = '' OR patronymic ILIKE '%' || $5 || '%') AND
-    ($6::text = '' OR address ILIKE '%' || $6 || '%')
+    ($6::text = '' OR address ILIKE '%' || $6 || '%') AND
+    ($7::text = '' OR enrichment_status::text = $7)
 ORDER BY id
 `
 
 type ListPeopleParams struct {
-	PassportSerie  int32  `json:"passport_serie"`
-	PassportNumber int32  `json:"passport_number"`
-	Surname        string `json:"surname"`
-	Name           string `json:"name"`
-	Patronymic     string `json:"patronymic"`
-	Address        string `json:"address"`
+	PassportSerie    int32  `json:"passport_serie"`
+	PassportNumber   int32  `json:"passport_number"`
+	Surname          string `json:"surname"`
+	Name             string `json:"name"`
+	Patronymic       string `json:"patronymic"`
+	Address          string `json:"address"`
+	EnrichmentStatus string `json:"enrichment_status"`
 }
 
 func (q *Queries) ListPeople(ctx context.Context, arg ListPeopleParams) ([]Person, error) {
@@ -121,6 +183,7 @@ func (q *Queries) ListPeople(ctx context.Context, arg ListPeopleParams) ([]Perso
 		arg.Name,
 		arg.Patronymic,
 		arg.Address,
+		arg.EnrichmentStatus,
 	)
 	if err != nil {
 		return nil, err
@@ -137,6 +200,12 @@ func (q *Queries) ListPeople(ctx context.Context, arg ListPeopleParams) ([]Perso
 			&i.PassportNumber,
 			&i.PassportSerie,
 			&i.Address,
+			&i.WeeklySeconds,
+			&i.WorkDays,
+			&i.WorkStartMinute,
+			&i.WorkEndMinute,
+			&i.EnrichmentStatus,
+			&i.EnrichmentError,
 		); err != nil {
 			return nil, err
 		}
@@ -152,27 +221,29 @@ func (q *Queries) ListPeople(ctx context.Context, arg ListPeopleParams) ([]Perso
 }
 
 const listPeopleWithLimit = `-- name: ListPeopleWithLimit :many
-SELECT id, name, surname, patronymic, passport_number, passport_serie, address FROM people
+SELECT id, name, surname, patronymic, passport_number, passport_serie, address, weekly_seconds, work_days, work_start_minute, work_end_minute, enrichment_status, enrichment_error FROM people
 WHERE
     ($3::int = 0 OR passport_serie = $3) AND
     ($4::int = 0 OR passport_number = $4) AND
     ($5::text = '' OR surname ILIKE '%' || $5 || '%') AND
     ($6::text = '' OR name ILIKE '%' || $6 || '%') AND
     ($7::text = '' OR patronymic ILIKE '%' || $7 || '%') AND
-    ($8::text = '' OR address ILIKE '%' || $8 || '%')
+    ($8::text = '' OR address ILIKE '%' || $8 || '%') AND
+    ($9::text = '' OR enrichment_status::text = $9)
 ORDER BY id
 LIMIT $1 OFFSET $2
 `
 
 type ListPeopleWithLimitParams struct {
-	Limit          int32  `json:"limit"`
-	Offset         int32  `json:"offset"`
-	PassportSerie  int32  `json:"passport_serie"`
-	PassportNumber int32  `json:"passport_number"`
-	Surname        string `json:"surname"`
-	Name           string `json:"name"`
-	Patronymic     string `json:"patronymic"`
-	Address        string `json:"address"`
+	Limit            int32  `json:"limit"`
+	Offset           int32  `json:"offset"`
+	PassportSerie    int32  `json:"passport_serie"`
+	PassportNumber   int32  `json:"passport_number"`
+	Surname          string `json:"surname"`
+	Name             string `json:"name"`
+	Patronymic       string `json:"patronymic"`
+	Address          string `json:"address"`
+	EnrichmentStatus string `json:"enrichment_status"`
 }
 
 func (q *Queries) ListPeopleWithLimit(ctx context.Context, arg ListPeopleWithLimitParams) ([]Person, error) {
@@ -185,6 +256,7 @@ func (q *Queries) ListPeopleWithLimit(ctx context.Context, arg ListPeopleWithLim
 		arg.Name,
 		arg.Patronymic,
 		arg.Address,
+		arg.EnrichmentStatus,
 	)
 	if err != nil {
 		return nil, err
@@ -201,6 +273,12 @@ func (q *Queries) ListPeopleWithLimit(ctx context.Context, arg ListPeopleWithLim
 			&i.PassportNumber,
 			&i.PassportSerie,
 			&i.Address,
+			&i.WeeklySeconds,
+			&i.WorkDays,
+			&i.WorkStartMinute,
+			&i.WorkEndMinute,
+			&i.EnrichmentStatus,
+			&i.EnrichmentError,
 		); err != nil {
 			return nil, err
 		}
@@ -215,6 +293,23 @@ func (q *Queries) ListPeopleWithLimit(ctx context.Context, arg ListPeopleWithLim
 	return items, nil
 }
 
+const setPersonEnrichmentStatus = `-- name: SetPersonEnrichmentStatus :exec
+UPDATE people
+SET enrichment_status = $2, enrichment_error = $3
+WHERE id = $1
+`
+
+type SetPersonEnrichmentStatusParams struct {
+	ID               int32            `json:"id"`
+	EnrichmentStatus EnrichmentStatus `json:"enrichment_status"`
+	EnrichmentError  string           `json:"enrichment_error"`
+}
+
+func (q *Queries) SetPersonEnrichmentStatus(ctx context.Context, arg SetPersonEnrichmentStatusParams) error {
+	_, err := q.db.ExecContext(ctx, setPersonEnrichmentStatus, arg.ID, arg.EnrichmentStatus, arg.EnrichmentError)
+	return err
+}
+
 const updatePerson = `-- name: UpdatePerson :exec
 UPDATE people
 SET 
@@ -249,3 +344,28 @@ func (q *Queries) UpdatePerson(ctx context.Context, arg UpdatePersonParams) erro
 	)
 	return err
 }
+
+const updatePersonSchedule = `-- name: UpdatePersonSchedule :exec
+UPDATE people
+SET weekly_seconds = $2, work_days = $3, work_start_minute = $4, work_end_minute = $5
+WHERE id = $1
+`
+
+type UpdatePersonScheduleParams struct {
+	ID              int32 `json:"id"`
+	WeeklySeconds   int64 `json:"weekly_seconds"`
+	WorkDays        int32 `json:"work_days"`
+	WorkStartMinute int32 `json:"work_start_minute"`
+	WorkEndMinute   int32 `json:"work_end_minute"`
+}
+
+func (q *Queries) UpdatePersonSchedule(ctx context.Context, arg UpdatePersonScheduleParams) error {
+	_, err := q.db.ExecContext(ctx, updatePersonSchedule,
+		arg.ID,
+		arg.WeeklySeconds,
+		arg.WorkDays,
+		arg.WorkStartMinute,
+		arg.WorkEndMinute,
+	)
+	return err
+}
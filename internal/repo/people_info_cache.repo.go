@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+	"time"
+)
+
+type PeopleInfoCacheRepo interface {
+	GetPeopleInfoCache(ctx context.Context, arg GetPeopleInfoCacheParams) (PeopleInfoCache, error)
+	UpsertPeopleInfoCache(ctx context.Context, arg UpsertPeopleInfoCacheParams) error
+	DeleteExpiredPeopleInfoCache(ctx context.Context, expiresAt time.Time) (int64, error)
+}
+
+func NewPeopleInfoCacheRepo(db DBTX) PeopleInfoCacheRepo {
+	return New(db)
+}
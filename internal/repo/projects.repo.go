@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+)
+
+type ProjectsRepo interface {
+	CreateClient(ctx context.Context, arg CreateClientParams) (int32, error)
+	DeleteClient(ctx context.Context, id int32) error
+	GetClientByID(ctx context.Context, id int32) (Client, error)
+	ListClients(ctx context.Context, name string) ([]Client, error)
+	UpdateClient(ctx context.Context, arg UpdateClientParams) error
+	CreateProject(ctx context.Context, arg CreateProjectParams) (int32, error)
+	DeleteProject(ctx context.Context, id int32) error
+	GetProjectByID(ctx context.Context, id int32) (Project, error)
+	ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error)
+	UpdateProject(ctx context.Context, arg UpdateProjectParams) error
+}
+
+func NewProjectsRepo(db DBTX) ProjectsRepo {
+	return New(db)
+}
@@ -6,21 +6,94 @@ package repo
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	AddTaskTag(ctx context.Context, arg AddTaskTagParams) error
+	ClaimEnrichmentJobs(ctx context.Context, arg ClaimEnrichmentJobsParams) ([]EnrichmentJob, error)
+	CloseTimeEntry(ctx context.Context, arg CloseTimeEntryParams) error
+	CountApprovedTimesheets(ctx context.Context, arg CountApprovedTimesheetsParams) (int64, error)
+	CountOverlappingInvoices(ctx context.Context, arg CountOverlappingInvoicesParams) (int64, error)
+	CountOverlappingTimeEntries(ctx context.Context, arg CountOverlappingTimeEntriesParams) (int64, error)
+	CountPeriodLocks(ctx context.Context, arg CountPeriodLocksParams) (int64, error)
+	CreateClient(ctx context.Context, arg CreateClientParams) (int32, error)
+	CreateClosedTimeEntry(ctx context.Context, arg CreateClosedTimeEntryParams) (int32, error)
+	CreateFinishedTask(ctx context.Context, arg CreateFinishedTaskParams) (int32, error)
+	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (int32, error)
+	CreateInvoiceLine(ctx context.Context, arg CreateInvoiceLineParams) error
+	CreatePendingPerson(ctx context.Context, arg CreatePendingPersonParams) (int32, error)
+	CreatePeriodLock(ctx context.Context, arg CreatePeriodLockParams) (int32, error)
 	CreatePerson(ctx context.Context, arg CreatePersonParams) (int32, error)
+	CreateProject(ctx context.Context, arg CreateProjectParams) (int32, error)
+	CreateRate(ctx context.Context, arg CreateRateParams) (int32, error)
 	CreateTask(ctx context.Context, arg CreateTaskParams) (int32, error)
+	CreateTaskAudit(ctx context.Context, arg CreateTaskAuditParams) error
+	CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) (int32, error)
+	CreateTimesheet(ctx context.Context, arg CreateTimesheetParams) (int32, error)
+	DeleteClient(ctx context.Context, id int32) error
+	DeleteEnrichmentJob(ctx context.Context, id int32) error
+	DeleteExpiredPeopleInfoCache(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeletePeriodLock(ctx context.Context, id int32) (int64, error)
 	DeletePerson(ctx context.Context, id int32) error
+	DeleteProject(ctx context.Context, id int32) error
+	DeleteRate(ctx context.Context, id int32) error
+	DeleteTimeEntriesByTaskID(ctx context.Context, taskID int32) error
+	EnrichPerson(ctx context.Context, arg EnrichPersonParams) error
+	GetBillableLines(ctx context.Context, arg GetBillableLinesParams) ([]GetBillableLinesRow, error)
+	GetCalendarEntriesByUserID(ctx context.Context, arg GetCalendarEntriesByUserIDParams) ([]GetCalendarEntriesByUserIDRow, error)
+	GetClientByID(ctx context.Context, id int32) (Client, error)
+	GetClientByIDForUpdate(ctx context.Context, id int32) (Client, error)
+	GetClippedTasksByUserID(ctx context.Context, arg GetClippedTasksByUserIDParams) ([]GetClippedTasksByUserIDRow, error)
+	GetInvoiceByID(ctx context.Context, id int32) (Invoice, error)
+	GetOpenTimeEntryByTaskID(ctx context.Context, taskID int32) (TimeEntry, error)
 	GetOrderedTasksByUserID(ctx context.Context, arg GetOrderedTasksByUserIDParams) ([]GetOrderedTasksByUserIDRow, error)
+	GetPeopleInfoCache(ctx context.Context, arg GetPeopleInfoCacheParams) (PeopleInfoCache, error)
 	GetPersonByID(ctx context.Context, id int32) (Person, error)
 	GetPersonByPassport(ctx context.Context, arg GetPersonByPassportParams) (Person, error)
+	GetPersonReport(ctx context.Context, arg GetPersonReportParams) ([]GetPersonReportRow, error)
+	GetPersonSessions(ctx context.Context, arg GetPersonSessionsParams) ([]GetPersonSessionsRow, error)
+	GetProjectBurndown(ctx context.Context, arg GetProjectBurndownParams) ([]GetProjectBurndownRow, error)
+	GetProjectByID(ctx context.Context, id int32) (Project, error)
+	GetProjectReport(ctx context.Context, arg GetProjectReportParams) ([]GetProjectReportRow, error)
+	GetProjectTrackedSeconds(ctx context.Context, arg GetProjectTrackedSecondsParams) (int64, error)
+	GetRateByID(ctx context.Context, id int32) (Rate, error)
+	GetRunningTaskByUserID(ctx context.Context, userID int32) (Task, error)
+	GetTagReport(ctx context.Context, arg GetTagReportParams) ([]GetTagReportRow, error)
 	GetTaskByID(ctx context.Context, id int32) (Task, error)
+	GetTeamReport(ctx context.Context, arg GetTeamReportParams) ([]GetTeamReportRow, error)
+	GetTimesheetByID(ctx context.Context, id int32) (Timesheet, error)
+	ListClients(ctx context.Context, name string) ([]Client, error)
+	ListInvoiceLines(ctx context.Context, invoiceID int32) ([]InvoiceLine, error)
+	ListInvoices(ctx context.Context, clientID int32) ([]Invoice, error)
 	ListPeople(ctx context.Context, arg ListPeopleParams) ([]Person, error)
 	ListPeopleWithLimit(ctx context.Context, arg ListPeopleWithLimitParams) ([]Person, error)
+	ListPeriodLocks(ctx context.Context, userID int32) ([]PeriodLock, error)
+	ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error)
+	ListRates(ctx context.Context, arg ListRatesParams) ([]Rate, error)
+	ListTags(ctx context.Context) ([]Tag, error)
+	ListTagsByTaskID(ctx context.Context, taskID int32) ([]string, error)
+	ListTasks(ctx context.Context, arg ListTasksParams) ([]ListTasksRow, error)
+	ListTasksOverEstimate(ctx context.Context, arg ListTasksOverEstimateParams) ([]ListTasksOverEstimateRow, error)
+	ListTimeEntriesByTaskID(ctx context.Context, taskID int32) ([]TimeEntry, error)
+	ListTimesheets(ctx context.Context, arg ListTimesheetsParams) ([]Timesheet, error)
+	RemoveTaskTag(ctx context.Context, arg RemoveTaskTagParams) (int64, error)
+	RenewEnrichmentJobLease(ctx context.Context, arg RenewEnrichmentJobLeaseParams) (int64, error)
+	RescheduleEnrichmentJob(ctx context.Context, arg RescheduleEnrichmentJobParams) error
+	SetPersonEnrichmentStatus(ctx context.Context, arg SetPersonEnrichmentStatusParams) error
 	SetTaskEndDate(ctx context.Context, arg SetTaskEndDateParams) error
 	SetTaskStartDate(ctx context.Context, arg SetTaskStartDateParams) error
+	SetTaskStatus(ctx context.Context, arg SetTaskStatusParams) error
+	SetTimesheetStatus(ctx context.Context, arg SetTimesheetStatusParams) (int64, error)
+	SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) error
+	UpdateClient(ctx context.Context, arg UpdateClientParams) error
 	UpdatePerson(ctx context.Context, arg UpdatePersonParams) error
+	UpdatePersonSchedule(ctx context.Context, arg UpdatePersonScheduleParams) error
+	UpdateProject(ctx context.Context, arg UpdateProjectParams) error
+	UpdateTaskTimes(ctx context.Context, arg UpdateTaskTimesParams) error
+	UpsertEnrichmentJob(ctx context.Context, arg UpsertEnrichmentJobParams) error
+	UpsertPeopleInfoCache(ctx context.Context, arg UpsertPeopleInfoCacheParams) error
+	UpsertTag(ctx context.Context, name string) (int32, error)
 }
 
 var _ Querier = (*Queries)(nil)
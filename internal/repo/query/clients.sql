@@ -0,0 +1,27 @@
+-- name: CreateClient :one
+INSERT INTO clients (name, address, created_at) VALUES ($1, $2, $3)
+RETURNING id;
+
+-- name: GetClientByID :one
+SELECT * FROM clients
+WHERE id = $1;
+
+-- name: GetClientByIDForUpdate :one
+SELECT * FROM clients
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListClients :many
+SELECT * FROM clients
+WHERE (sqlc.arg(name)::text = '' OR name ILIKE '%' || sqlc.arg(name) || '%')
+ORDER BY name, id;
+
+-- name: UpdateClient :exec
+UPDATE clients
+SET
+    name = COALESCE(NULLIF(sqlc.arg(name)::text, ''), name),
+    address = COALESCE(NULLIF(sqlc.arg(address)::text, ''), address)
+WHERE id = $1;
+
+-- name: DeleteClient :exec
+DELETE FROM clients WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: UpsertEnrichmentJob :exec
+INSERT INTO enrichment_jobs (person_id, run_at, created_at) VALUES ($1, $2, $2)
+ON CONFLICT (person_id) DO UPDATE
+SET attempts = 0, run_at = EXCLUDED.run_at, locked_until = NULL, last_error = '';
+
+-- name: ClaimEnrichmentJobs :many
+UPDATE enrichment_jobs
+SET attempts = attempts + 1, locked_until = sqlc.arg(locked_until)::timestamp
+WHERE id IN (
+    SELECT j.id FROM enrichment_jobs j
+    WHERE j.run_at <= sqlc.arg(now)::timestamp AND
+        (j.locked_until IS NULL OR j.locked_until <= sqlc.arg(now)::timestamp)
+    ORDER BY j.run_at
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RenewEnrichmentJobLease :execrows
+UPDATE enrichment_jobs
+SET locked_until = sqlc.arg(locked_until)::timestamp
+WHERE id = sqlc.arg(id) AND locked_until = sqlc.arg(claimed_until)::timestamp;
+
+-- name: RescheduleEnrichmentJob :exec
+UPDATE enrichment_jobs
+SET run_at = $2, locked_until = NULL, last_error = $3
+WHERE id = $1;
+
+-- name: DeleteEnrichmentJob :exec
+DELETE FROM enrichment_jobs WHERE id = $1;
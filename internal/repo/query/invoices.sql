@@ -0,0 +1,58 @@
+-- name: GetBillableLines :many
+SELECT pr.id AS project_id, pr.name AS project_name, p.id AS user_id,
+    CAST(p.surname || ' ' || p.name AS TEXT) AS person_name,
+    CAST(rate.amount * 100 AS BIGINT) AS rate_cents,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(te.end_dt, sqlc.arg(to_dt)::timestamp) - GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+    )) AS BIGINT) AS total_seconds,
+    CAST(ROUND(SUM(EXTRACT(EPOCH FROM
+        LEAST(te.end_dt, sqlc.arg(to_dt)::timestamp) - GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+    )::numeric * rate.amount) / 36) AS BIGINT) AS amount_cents
+FROM tasks t
+JOIN projects pr ON pr.id = t.project_id
+JOIN people p ON p.id = t.user_id
+JOIN time_entries te ON te.task_id = t.id
+JOIN LATERAL (
+    SELECT r.amount FROM rates r
+    WHERE (r.task_id = t.id OR r.project_id = t.project_id OR r.user_id = t.user_id) AND
+        r.effective_from <= te.start_dt
+    ORDER BY r.task_id IS NULL, r.project_id IS NULL, r.effective_from DESC
+    LIMIT 1
+) rate ON true
+WHERE pr.client_id = sqlc.arg(client_id) AND
+    t.deleted_at IS NULL AND
+    t.billable AND
+    te.end_dt IS NOT NULL AND
+    te.start_dt < sqlc.arg(to_dt)::timestamp AND
+    te.end_dt > sqlc.arg(from_dt)::timestamp
+GROUP BY pr.id, p.id, rate.amount
+ORDER BY pr.name, pr.id, p.surname, p.name, p.id, rate.amount;
+
+-- name: CountOverlappingInvoices :one
+SELECT COUNT(*) FROM invoices
+WHERE client_id = sqlc.arg(client_id)::int AND
+    from_dt < sqlc.arg(to_dt)::timestamp AND
+    to_dt > sqlc.arg(from_dt)::timestamp;
+
+-- name: CreateInvoice :one
+INSERT INTO invoices (number, client_id, client_name, client_address, from_dt, to_dt, tax_rate, subtotal, tax, total, created_at)
+VALUES ('INV-' || lpad(nextval('invoice_number_seq')::text, 6, '0'), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id;
+
+-- name: CreateInvoiceLine :exec
+INSERT INTO invoice_lines (invoice_id, project_id, project_name, user_id, person_name, rate, seconds, amount)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetInvoiceByID :one
+SELECT * FROM invoices
+WHERE id = $1;
+
+-- name: ListInvoices :many
+SELECT * FROM invoices
+WHERE sqlc.arg(client_id)::int = 0 OR client_id = sqlc.arg(client_id)
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListInvoiceLines :many
+SELECT * FROM invoice_lines
+WHERE invoice_id = $1
+ORDER BY id;
@@ -0,0 +1,17 @@
+-- name: CreatePeriodLock :one
+INSERT INTO period_locks (user_id, from_dt, to_dt, locked_by, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: DeletePeriodLock :execrows
+DELETE FROM period_locks WHERE id = $1;
+
+-- name: ListPeriodLocks :many
+SELECT * FROM period_locks
+WHERE sqlc.arg(user_id)::int = 0 OR user_id IS NULL OR user_id = sqlc.arg(user_id)
+ORDER BY from_dt DESC, id;
+
+-- name: CountPeriodLocks :one
+SELECT COUNT(*) FROM period_locks
+WHERE (user_id IS NULL OR user_id = sqlc.arg(user_id)) AND
+    from_dt <= sqlc.arg(to_dt)::timestamp AND
+    to_dt > sqlc.arg(from_dt)::timestamp;
@@ -14,7 +14,8 @@ WHERE
     (sqlc.arg(surname)::text = '' OR surname ILIKE '%' || sqlc.arg(surname) || '%') AND
     (sqlc.arg(name)::text = '' OR name ILIKE '%' || sqlc.arg(name) || '%') AND
     (sqlc.arg(patronymic)::text = '' OR patronymic ILIKE '%' || sqlc.arg(patronymic) || '%') AND
-    (sqlc.arg(address)::text = '' OR address ILIKE '%' || sqlc.arg(address) || '%')
+    (sqlc.arg(address)::text = '' OR address ILIKE '%' || sqlc.arg(address) || '%') AND
+    (sqlc.arg(enrichment_status)::text = '' OR enrichment_status::text = sqlc.arg(enrichment_status))
 ORDER BY id
 LIMIT $1 OFFSET $2;
 
@@ -26,7 +27,8 @@ WHERE
     (sqlc.arg(surname)::text = '' OR surname ILIKE '%' || sqlc.arg(surname) || '%') AND
     (sqlc.arg(name)::text = '' OR name ILIKE '%' || sqlc.arg(name) || '%') AND
     (sqlc.arg(patronymic)::text = '' OR patronymic ILIKE '%' || sqlc.arg(patronymic) || '%') AND
-    (sqlc.arg(address)::text = '' OR address ILIKE '%' || sqlc.arg(address) || '%')
+    (sqlc.arg(address)::text = '' OR address ILIKE '%' || sqlc.arg(address) || '%') AND
+    (sqlc.arg(enrichment_status)::text = '' OR enrichment_status::text = sqlc.arg(enrichment_status))
 ORDER BY id;
 
 -- name: CreatePerson :one
@@ -46,3 +48,28 @@ WHERE id = $1;
 
 -- name: DeletePerson :exec
 DELETE FROM people WHERE id = $1;
+
+-- name: UpdatePersonSchedule :exec
+UPDATE people
+SET weekly_seconds = $2, work_days = $3, work_start_minute = $4, work_end_minute = $5
+WHERE id = $1;
+
+-- name: CreatePendingPerson :one
+INSERT INTO people (name, surname, address, passport_number, passport_serie, enrichment_status) VALUES ('', '', '', $1, $2, 'pending')
+RETURNING id;
+
+-- name: EnrichPerson :exec
+UPDATE people
+SET
+    name = COALESCE(NULLIF(name, ''), $2),
+    surname = COALESCE(NULLIF(surname, ''), $3),
+    patronymic = COALESCE(NULLIF(patronymic, ''), $4),
+    address = COALESCE(NULLIF(address, ''), $5),
+    enrichment_status = 'enriched',
+    enrichment_error = ''
+WHERE id = $1;
+
+-- name: SetPersonEnrichmentStatus :exec
+UPDATE people
+SET enrichment_status = $2, enrichment_error = $3
+WHERE id = $1;
@@ -0,0 +1,13 @@
+-- name: GetPeopleInfoCache :one
+SELECT * FROM people_info_cache
+WHERE passport_serie = $1 AND passport_number = $2 AND expires_at > $3;
+
+-- name: UpsertPeopleInfoCache :exec
+INSERT INTO people_info_cache (passport_serie, passport_number, result, surname, name, patronymic, address, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (passport_serie, passport_number) DO UPDATE
+SET result = EXCLUDED.result, surname = EXCLUDED.surname, name = EXCLUDED.name,
+    patronymic = EXCLUDED.patronymic, address = EXCLUDED.address, expires_at = EXCLUDED.expires_at;
+
+-- name: DeleteExpiredPeopleInfoCache :execrows
+DELETE FROM people_info_cache WHERE expires_at <= $1;
@@ -0,0 +1,20 @@
+-- name: CreateProject :one
+INSERT INTO projects (client_id, name, created_at, budget_seconds) VALUES ($1, $2, $3, $4)
+RETURNING id;
+
+-- name: GetProjectByID :one
+SELECT * FROM projects
+WHERE id = $1;
+
+-- name: ListProjects :many
+SELECT * FROM projects
+WHERE
+    (sqlc.arg(client_id)::int = 0 OR client_id = sqlc.arg(client_id)) AND
+    (sqlc.arg(name)::text = '' OR name ILIKE '%' || sqlc.arg(name) || '%')
+ORDER BY name, id;
+
+-- name: UpdateProject :exec
+UPDATE projects SET client_id = $2, name = $3, budget_seconds = $4 WHERE id = $1;
+
+-- name: DeleteProject :exec
+DELETE FROM projects WHERE id = $1;
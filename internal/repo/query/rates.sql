@@ -0,0 +1,18 @@
+-- name: CreateRate :one
+INSERT INTO rates (user_id, project_id, task_id, amount, effective_from, created_at) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: GetRateByID :one
+SELECT * FROM rates
+WHERE id = $1;
+
+-- name: ListRates :many
+SELECT * FROM rates
+WHERE
+    (sqlc.arg(user_id)::int = 0 OR user_id = sqlc.arg(user_id)) AND
+    (sqlc.arg(project_id)::int = 0 OR project_id = sqlc.arg(project_id)) AND
+    (sqlc.arg(task_id)::int = 0 OR task_id = sqlc.arg(task_id))
+ORDER BY effective_from DESC, id DESC;
+
+-- name: DeleteRate :exec
+DELETE FROM rates WHERE id = $1;
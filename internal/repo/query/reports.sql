@@ -0,0 +1,204 @@
+-- name: GetPersonReport :many
+WITH buckets AS (
+    SELECT generate_series(
+        date_trunc(sqlc.arg(period)::text, sqlc.arg(from_dt)::timestamp),
+        sqlc.arg(to_dt)::timestamp,
+        ('1 ' || sqlc.arg(period)::text)::interval
+    )::timestamp AS bucket_start
+)
+SELECT b.bucket_start, t.id AS task_id, t.description,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), b.bucket_start + ('1 ' || sqlc.arg(period)::text)::interval, sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, b.bucket_start, sqlc.arg(from_dt)::timestamp)
+    )) AS BIGINT) AS total_seconds,
+    CAST(ROUND(COALESCE(SUM(EXTRACT(EPOCH FROM
+        LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), b.bucket_start + ('1 ' || sqlc.arg(period)::text)::interval, sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, b.bucket_start, sqlc.arg(from_dt)::timestamp)
+    )::numeric * rate.amount), 0) / 36) AS BIGINT) AS amount_cents
+FROM buckets b
+JOIN time_entries te ON
+    te.start_dt < LEAST(b.bucket_start + ('1 ' || sqlc.arg(period)::text)::interval, sqlc.arg(to_dt)::timestamp) AND
+    COALESCE(te.end_dt, sqlc.narg(now)::timestamp) > GREATEST(b.bucket_start, sqlc.arg(from_dt)::timestamp)
+JOIN tasks t ON t.id = te.task_id
+LEFT JOIN LATERAL (
+    SELECT r.amount FROM rates r
+    WHERE (r.task_id = t.id OR r.project_id = t.project_id OR r.user_id = t.user_id) AND
+        r.effective_from <= te.start_dt
+    ORDER BY r.task_id IS NULL, r.project_id IS NULL, r.effective_from DESC
+    LIMIT 1
+) rate ON t.billable
+WHERE t.user_id = sqlc.arg(user_id) AND t.deleted_at IS NULL AND t.status <> 'cancelled'
+GROUP BY b.bucket_start, t.id
+ORDER BY b.bucket_start, total_seconds DESC, t.id;
+
+-- name: GetTeamReport :many
+WITH task_totals AS (
+    SELECT t.user_id, t.id AS task_id,
+        EXTRACT(EPOCH FROM SUM(
+            LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(to_dt)::timestamp) - GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+        )) AS seconds,
+        SUM(EXTRACT(EPOCH FROM
+            LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(to_dt)::timestamp) - GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+        )::numeric * rate.amount) AS amount
+    FROM tasks t
+    JOIN time_entries te ON te.task_id = t.id
+    LEFT JOIN LATERAL (
+        SELECT r.amount FROM rates r
+        WHERE (r.task_id = t.id OR r.project_id = t.project_id OR r.user_id = t.user_id) AND
+            r.effective_from <= te.start_dt
+        ORDER BY r.task_id IS NULL, r.project_id IS NULL, r.effective_from DESC
+        LIMIT 1
+    ) rate ON t.billable
+    WHERE t.deleted_at IS NULL AND t.status <> 'cancelled' AND te.start_dt < sqlc.arg(to_dt)::timestamp AND
+        COALESCE(te.end_dt, sqlc.narg(now)::timestamp) > sqlc.arg(from_dt)::timestamp
+    GROUP BY t.user_id, t.id
+), team AS (
+    SELECT p.id, p.name, p.surname, p.patronymic, p.passport_number, p.passport_serie, p.address,
+        CAST(COUNT(tt.task_id) AS INT) AS task_count,
+        CAST(COALESCE(SUM(tt.seconds), 0) AS BIGINT) AS total_seconds,
+        CAST(COALESCE((ARRAY_AGG(tt.task_id ORDER BY tt.seconds DESC))[1], 0) AS INT) AS longest_task_id,
+        CAST(COALESCE(MAX(tt.seconds), 0) AS BIGINT) AS longest_task_seconds,
+        CAST(COALESCE(AVG(tt.seconds), 0) AS BIGINT) AS average_task_seconds,
+        CAST(ROUND(COALESCE(SUM(tt.amount), 0) / 36) AS BIGINT) AS amount_cents
+    FROM people p
+    LEFT JOIN task_totals tt ON tt.user_id = p.id
+    WHERE
+        (sqlc.arg(passport_serie)::int = 0 OR p.passport_serie = sqlc.arg(passport_serie)) AND
+        (sqlc.arg(passport_number)::int = 0 OR p.passport_number = sqlc.arg(passport_number)) AND
+        (sqlc.arg(surname)::text = '' OR p.surname ILIKE '%' || sqlc.arg(surname) || '%') AND
+        (sqlc.arg(name)::text = '' OR p.name ILIKE '%' || sqlc.arg(name) || '%') AND
+        (sqlc.arg(patronymic)::text = '' OR p.patronymic ILIKE '%' || sqlc.arg(patronymic) || '%') AND
+        (sqlc.arg(address)::text = '' OR p.address ILIKE '%' || sqlc.arg(address) || '%')
+    GROUP BY p.id
+)
+SELECT id, name, surname, patronymic, passport_number, passport_serie, address,
+    task_count, total_seconds, longest_task_id, longest_task_seconds, average_task_seconds, amount_cents
+FROM team
+ORDER BY
+    CASE WHEN NOT sqlc.arg(sort_desc)::bool AND sqlc.arg(sort_by)::text = 'surname' THEN surname END ASC,
+    CASE WHEN sqlc.arg(sort_desc)::bool AND sqlc.arg(sort_by)::text = 'surname' THEN surname END DESC,
+    CASE WHEN NOT sqlc.arg(sort_desc)::bool AND sqlc.arg(sort_by)::text = 'name' THEN name END ASC,
+    CASE WHEN sqlc.arg(sort_desc)::bool AND sqlc.arg(sort_by)::text = 'name' THEN name END DESC,
+    CASE WHEN NOT sqlc.arg(sort_desc)::bool THEN
+        CASE sqlc.arg(sort_by)::text
+            WHEN 'total_seconds' THEN total_seconds
+            WHEN 'task_count' THEN task_count
+            WHEN 'longest_task_seconds' THEN longest_task_seconds
+            WHEN 'average_task_seconds' THEN average_task_seconds
+        END
+    END ASC,
+    CASE WHEN sqlc.arg(sort_desc)::bool THEN
+        CASE sqlc.arg(sort_by)::text
+            WHEN 'total_seconds' THEN total_seconds
+            WHEN 'task_count' THEN task_count
+            WHEN 'longest_task_seconds' THEN longest_task_seconds
+            WHEN 'average_task_seconds' THEN average_task_seconds
+        END
+    END DESC,
+    id
+LIMIT sqlc.narg(limit) OFFSET sqlc.arg(offset);
+
+-- name: GetProjectReport :many
+SELECT CAST(COALESCE(c.id, 0) AS INT) AS client_id, CAST(COALESCE(c.name, '') AS TEXT) AS client_name,
+    CAST(COALESCE(pr.id, 0) AS INT) AS project_id, CAST(COALESCE(pr.name, '') AS TEXT) AS project_name,
+    CAST(COUNT(DISTINCT t.id) AS INT) AS task_count,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+    )) AS BIGINT) AS total_seconds,
+    CAST(ROUND(COALESCE(SUM(EXTRACT(EPOCH FROM
+        LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+    )::numeric * rate.amount), 0) / 36) AS BIGINT) AS amount_cents
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+LEFT JOIN LATERAL (
+    SELECT r.amount FROM rates r
+    WHERE (r.task_id = t.id OR r.project_id = t.project_id OR r.user_id = t.user_id) AND
+        r.effective_from <= te.start_dt
+    ORDER BY r.task_id IS NULL, r.project_id IS NULL, r.effective_from DESC
+    LIMIT 1
+) rate ON t.billable
+LEFT JOIN projects pr ON pr.id = t.project_id
+LEFT JOIN clients c ON c.id = pr.client_id
+WHERE t.deleted_at IS NULL AND t.status <> 'cancelled' AND
+    te.start_dt < sqlc.arg(to_dt)::timestamp AND
+    COALESCE(te.end_dt, sqlc.narg(now)::timestamp) > sqlc.arg(from_dt)::timestamp AND
+    (sqlc.arg(client_id)::int = 0 OR c.id = sqlc.arg(client_id)) AND
+    (sqlc.arg(project_id)::int = 0 OR pr.id = sqlc.arg(project_id))
+GROUP BY c.id, pr.id
+ORDER BY c.name NULLS LAST, c.id, total_seconds DESC, pr.id;
+
+-- name: GetTagReport :many
+SELECT p.id AS user_id, p.surname, p.name, tg.name AS tag,
+    CAST(COUNT(DISTINCT t.id) AS INT) AS task_count,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+    )) AS BIGINT) AS total_seconds,
+    CAST(ROUND(COALESCE(SUM(EXTRACT(EPOCH FROM
+        LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+    )::numeric * rate.amount), 0) / 36) AS BIGINT) AS amount_cents
+FROM tasks t
+JOIN people p ON p.id = t.user_id
+JOIN task_tags tt ON tt.task_id = t.id
+JOIN tags tg ON tg.id = tt.tag_id
+JOIN time_entries te ON te.task_id = t.id
+LEFT JOIN LATERAL (
+    SELECT r.amount FROM rates r
+    WHERE (r.task_id = t.id OR r.project_id = t.project_id OR r.user_id = t.user_id) AND
+        r.effective_from <= te.start_dt
+    ORDER BY r.task_id IS NULL, r.project_id IS NULL, r.effective_from DESC
+    LIMIT 1
+) rate ON t.billable
+WHERE t.deleted_at IS NULL AND t.status <> 'cancelled' AND
+    te.start_dt < sqlc.arg(to_dt)::timestamp AND
+    COALESCE(te.end_dt, sqlc.narg(now)::timestamp) > sqlc.arg(from_dt)::timestamp AND
+    (sqlc.arg(user_id)::int = 0 OR t.user_id = sqlc.arg(user_id))
+GROUP BY p.id, tg.id
+ORDER BY p.surname, p.name, p.id, total_seconds DESC, tg.name;
+
+-- name: GetProjectBurndown :many
+WITH buckets AS (
+    SELECT generate_series(
+        date_trunc(sqlc.arg(period)::text, sqlc.arg(from_dt)::timestamp),
+        sqlc.arg(to_dt)::timestamp,
+        ('1 ' || sqlc.arg(period)::text)::interval
+    )::timestamp AS bucket_start
+)
+SELECT b.bucket_start,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), b.bucket_start + ('1 ' || sqlc.arg(period)::text)::interval, sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, b.bucket_start, sqlc.arg(from_dt)::timestamp)
+    )) AS BIGINT) AS total_seconds
+FROM buckets b
+JOIN time_entries te ON
+    te.start_dt < LEAST(b.bucket_start + ('1 ' || sqlc.arg(period)::text)::interval, sqlc.arg(to_dt)::timestamp) AND
+    COALESCE(te.end_dt, sqlc.narg(now)::timestamp) > GREATEST(b.bucket_start, sqlc.arg(from_dt)::timestamp)
+JOIN tasks t ON t.id = te.task_id
+WHERE t.project_id = sqlc.arg(project_id) AND t.deleted_at IS NULL AND t.status <> 'cancelled'
+GROUP BY b.bucket_start
+ORDER BY b.bucket_start;
+
+-- name: GetProjectTrackedSeconds :one
+SELECT CAST(COALESCE(EXTRACT(EPOCH FROM SUM(
+    LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(before_dt)::timestamp) - te.start_dt
+)), 0) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.project_id = sqlc.arg(project_id) AND
+    t.deleted_at IS NULL AND t.status <> 'cancelled' AND
+    te.start_dt < sqlc.arg(before_dt)::timestamp AND
+    COALESCE(te.end_dt, sqlc.narg(now)::timestamp) > te.start_dt;
+
+-- name: GetPersonSessions :many
+SELECT GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)::timestamp AS start_dt,
+    LEAST(COALESCE(te.end_dt, sqlc.narg(now)::timestamp), sqlc.arg(to_dt)::timestamp)::timestamp AS end_dt
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.user_id = sqlc.arg(user_id) AND
+    t.deleted_at IS NULL AND t.status <> 'cancelled' AND
+    te.start_dt < sqlc.arg(to_dt)::timestamp AND
+    COALESCE(te.end_dt, sqlc.narg(now)::timestamp) > sqlc.arg(from_dt)::timestamp
+ORDER BY te.start_dt;
@@ -0,0 +1,23 @@
+-- name: UpsertTag :one
+INSERT INTO tags (name) VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id;
+
+-- name: AddTaskTag :exec
+INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveTaskTag :execrows
+DELETE FROM task_tags tt
+USING tags tg
+WHERE tt.tag_id = tg.id AND tt.task_id = sqlc.arg(task_id) AND tg.name = sqlc.arg(name);
+
+-- name: ListTagsByTaskID :many
+SELECT tg.name FROM tags tg
+JOIN task_tags tt ON tt.tag_id = tg.id
+WHERE tt.task_id = $1
+ORDER BY tg.name;
+
+-- name: ListTags :many
+SELECT * FROM tags
+ORDER BY name;
@@ -1,5 +1,5 @@
 -- name: CreateTask :one
-INSERT INTO tasks (user_id, description, created_at) VALUES ($1, $2, $3)
+INSERT INTO tasks (user_id, description, created_at, project_id) VALUES ($1, $2, $3, $4)
 RETURNING id;
 
 -- name: SetTaskStartDate :exec
@@ -9,12 +9,111 @@ UPDATE tasks SET start_dt = $1 WHERE id = $2;
 UPDATE tasks SET end_dt = $1 WHERE id = $2;
 
 -- name: GetOrderedTasksByUserID :many
-SELECT *, CAST(EXTRACT(HOUR from end_dt - start_dt) AS INT) AS hours, 
-    CAST(EXTRACT(MINUTE from end_dt - start_dt) AS INT) as minutes  FROM tasks 
-WHERE user_id = $1 AND 
-start_dt >= $2 AND
-end_dt <=  $3 
-GROUP BY id ORDER BY hours DESC;
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.billable,
+    CAST(EXTRACT(EPOCH FROM SUM(te.end_dt - te.start_dt)) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.user_id = $1 AND
+t.deleted_at IS NULL AND
+t.start_dt >= $2 AND
+t.end_dt <= $3 AND
+(sqlc.arg(tag)::text = '' OR EXISTS (
+    SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+    WHERE tt.task_id = t.id AND tg.name = sqlc.arg(tag)
+))
+GROUP BY t.id ORDER BY total_seconds DESC, t.id;
+
+-- name: GetClippedTasksByUserID :many
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.billable,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(COALESCE(te.end_dt, sqlc.arg(now)::timestamp), sqlc.arg(to_dt)::timestamp) -
+        GREATEST(te.start_dt, sqlc.arg(from_dt)::timestamp)
+    )) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.user_id = sqlc.arg(user_id) AND
+t.deleted_at IS NULL AND
+t.status <> 'cancelled' AND
+te.start_dt < sqlc.arg(to_dt)::timestamp AND
+COALESCE(te.end_dt, sqlc.arg(now)::timestamp) > sqlc.arg(from_dt)::timestamp AND
+(sqlc.arg(tag)::text = '' OR EXISTS (
+    SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+    WHERE tt.task_id = t.id AND tg.name = sqlc.arg(tag)
+))
+GROUP BY t.id ORDER BY total_seconds DESC, t.id;
 
 -- name: GetTaskByID :one
-SELECT * FROM tasks WHERE id = $1;
+SELECT * FROM tasks WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: SetTaskStatus :exec
+UPDATE tasks SET status = $1 WHERE id = $2;
+
+-- name: GetRunningTaskByUserID :one
+SELECT * FROM tasks WHERE user_id = $1 AND status = 'running' AND deleted_at IS NULL;
+
+-- name: GetCalendarEntriesByUserID :many
+SELECT t.id AS task_id, t.description, t.status, t.start_dt AS task_start_dt, t.end_dt AS task_end_dt,
+    te.id AS entry_id, te.start_dt, te.end_dt
+FROM tasks t
+LEFT JOIN time_entries te ON te.task_id = t.id
+WHERE t.user_id = sqlc.arg(user_id) AND
+t.deleted_at IS NULL AND
+COALESCE(te.start_dt, t.start_dt) < sqlc.arg(to_dt)::timestamp AND
+COALESCE(te.end_dt, t.end_dt, sqlc.arg(now)::timestamp) > sqlc.arg(from_dt)::timestamp
+ORDER BY COALESCE(te.start_dt, t.start_dt), t.id, te.id;
+
+-- name: CreateFinishedTask :one
+INSERT INTO tasks (user_id, description, start_dt, end_dt, created_at, status, project_id) VALUES ($1, $2, $3, $4, $5, 'done', $6)
+RETURNING id;
+
+-- name: UpdateTaskTimes :exec
+UPDATE tasks SET description = $2, start_dt = $3, end_dt = $4, status = $5, project_id = $6, billable = $7, estimate_seconds = $8 WHERE id = $1;
+
+-- name: CountOverlappingTimeEntries :one
+SELECT COUNT(*) FROM time_entries te
+JOIN tasks t ON t.id = te.task_id
+WHERE t.user_id = sqlc.arg(user_id) AND
+t.id <> sqlc.arg(task_id) AND
+t.deleted_at IS NULL AND
+te.start_dt < sqlc.arg(end_dt)::timestamp AND
+COALESCE(te.end_dt, sqlc.arg(now)::timestamp) > sqlc.arg(start_dt)::timestamp;
+
+-- name: CreateTaskAudit :exec
+INSERT INTO task_audit (task_id, changed_by, changed_at, note) VALUES ($1, $2, $3, $4);
+
+-- name: ListTasks :many
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.project_id, t.billable, t.estimate_seconds,
+    CAST(COALESCE(EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, sqlc.arg(now)::timestamp) - te.start_dt)), 0) AS BIGINT) AS total_seconds
+FROM tasks t
+LEFT JOIN time_entries te ON te.task_id = t.id
+WHERE t.deleted_at IS NULL AND
+    (sqlc.arg(user_id)::int = 0 OR t.user_id = sqlc.arg(user_id)) AND
+    (sqlc.arg(project_id)::int = 0 OR t.project_id = sqlc.arg(project_id)) AND
+    (sqlc.narg(status)::task_status IS NULL OR t.status = sqlc.narg(status)) AND
+    (sqlc.arg(query)::text = '' OR to_tsvector('simple', t.description) @@ plainto_tsquery('simple', sqlc.arg(query))) AND
+    (sqlc.narg(created_from)::timestamp IS NULL OR t.created_at >= sqlc.narg(created_from)) AND
+    (sqlc.narg(created_to)::timestamp IS NULL OR t.created_at < sqlc.narg(created_to)) AND
+    (NOT sqlc.arg(running_only)::bool OR t.status = 'running') AND
+    (sqlc.arg(tag)::text = '' OR EXISTS (
+        SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+        WHERE tt.task_id = t.id AND tg.name = sqlc.arg(tag)
+    ))
+GROUP BY t.id
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT sqlc.narg(limit) OFFSET sqlc.arg(offset);
+
+-- name: SoftDeleteTask :exec
+UPDATE tasks SET deleted_at = $1 WHERE id = $2;
+
+-- name: ListTasksOverEstimate :many
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.project_id, t.billable, t.estimate_seconds,
+    CAST(EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, sqlc.arg(now)::timestamp) - te.start_dt)) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.deleted_at IS NULL AND
+    t.estimate_seconds IS NOT NULL AND
+    (sqlc.arg(user_id)::int = 0 OR t.user_id = sqlc.arg(user_id)) AND
+    (sqlc.arg(project_id)::int = 0 OR t.project_id = sqlc.arg(project_id))
+GROUP BY t.id
+HAVING EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, sqlc.arg(now)::timestamp) - te.start_dt)) > t.estimate_seconds
+ORDER BY EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, sqlc.arg(now)::timestamp) - te.start_dt)) - t.estimate_seconds DESC, t.id;
@@ -0,0 +1,20 @@
+-- name: CreateTimeEntry :one
+INSERT INTO time_entries (task_id, start_dt) VALUES ($1, $2)
+RETURNING id;
+
+-- name: GetOpenTimeEntryByTaskID :one
+SELECT * FROM time_entries WHERE task_id = $1 AND end_dt IS NULL;
+
+-- name: CloseTimeEntry :exec
+UPDATE time_entries SET end_dt = $1 WHERE task_id = $2 AND end_dt IS NULL;
+
+-- name: ListTimeEntriesByTaskID :many
+SELECT * FROM time_entries WHERE task_id = $1
+ORDER BY start_dt;
+
+-- name: CreateClosedTimeEntry :one
+INSERT INTO time_entries (task_id, start_dt, end_dt) VALUES ($1, $2, $3)
+RETURNING id;
+
+-- name: DeleteTimeEntriesByTaskID :exec
+DELETE FROM time_entries WHERE task_id = $1;
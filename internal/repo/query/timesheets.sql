@@ -0,0 +1,25 @@
+-- name: CreateTimesheet :one
+INSERT INTO timesheets (user_id, week_start, created_at) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, week_start) DO NOTHING
+RETURNING id;
+
+-- name: GetTimesheetByID :one
+SELECT * FROM timesheets WHERE id = $1;
+
+-- name: ListTimesheets :many
+SELECT * FROM timesheets
+WHERE (sqlc.arg(user_id)::int = 0 OR user_id = sqlc.arg(user_id)) AND
+    (sqlc.narg(status)::timesheet_status IS NULL OR status = sqlc.narg(status))
+ORDER BY week_start DESC, user_id;
+
+-- name: SetTimesheetStatus :execrows
+UPDATE timesheets SET status = sqlc.arg(status), comment = sqlc.arg(comment), submitted_at = sqlc.arg(submitted_at),
+    reviewed_by = sqlc.arg(reviewed_by), reviewed_at = sqlc.arg(reviewed_at)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status);
+
+-- name: CountApprovedTimesheets :one
+SELECT COUNT(*) FROM timesheets
+WHERE user_id = sqlc.arg(user_id) AND
+    status = 'approved' AND
+    week_start <= sqlc.arg(to_dt)::timestamp AND
+    week_start + interval '7 days' > sqlc.arg(from_dt)::timestamp;
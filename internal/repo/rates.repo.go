@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+)
+
+type RatesRepo interface {
+	CreateRate(ctx context.Context, arg CreateRateParams) (int32, error)
+	DeleteRate(ctx context.Context, id int32) error
+	GetRateByID(ctx context.Context, id int32) (Rate, error)
+	ListRates(ctx context.Context, arg ListRatesParams) ([]Rate, error)
+	GetPersonByID(ctx context.Context, id int32) (Person, error)
+	GetProjectByID(ctx context.Context, id int32) (Project, error)
+	GetTaskByID(ctx context.Context, id int32) (Task, error)
+}
+
+func NewRatesRepo(db DBTX) RatesRepo {
+	return New(db)
+}
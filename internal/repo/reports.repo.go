@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"context"
+)
+
+type ReportsRepo interface {
+	GetPersonReport(ctx context.Context, arg GetPersonReportParams) ([]GetPersonReportRow, error)
+	GetPersonSessions(ctx context.Context, arg GetPersonSessionsParams) ([]GetPersonSessionsRow, error)
+	GetTeamReport(ctx context.Context, arg GetTeamReportParams) ([]GetTeamReportRow, error)
+	GetProjectReport(ctx context.Context, arg GetProjectReportParams) ([]GetProjectReportRow, error)
+	GetTagReport(ctx context.Context, arg GetTagReportParams) ([]GetTagReportRow, error)
+	GetProjectBurndown(ctx context.Context, arg GetProjectBurndownParams) ([]GetProjectBurndownRow, error)
+	GetProjectTrackedSeconds(ctx context.Context, arg GetProjectTrackedSecondsParams) (int64, error)
+	GetPersonByID(ctx context.Context, id int32) (Person, error)
+	GetProjectByID(ctx context.Context, id int32) (Project, error)
+}
+
+func NewReportsRepo(db DBTX) ReportsRepo {
+	return New(db)
+}
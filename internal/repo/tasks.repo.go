@@ -2,16 +2,76 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 )
 
 type TasksRepo interface {
 	CreateTask(ctx context.Context, arg CreateTaskParams) (int32, error)
+	CreateFinishedTask(ctx context.Context, arg CreateFinishedTaskParams) (int32, error)
 	GetOrderedTasksByUserID(ctx context.Context, arg GetOrderedTasksByUserIDParams) ([]GetOrderedTasksByUserIDRow, error)
+	GetClippedTasksByUserID(ctx context.Context, arg GetClippedTasksByUserIDParams) ([]GetClippedTasksByUserIDRow, error)
 	SetTaskEndDate(ctx context.Context, arg SetTaskEndDateParams) error
 	SetTaskStartDate(ctx context.Context, arg SetTaskStartDateParams) error
+	SetTaskStatus(ctx context.Context, arg SetTaskStatusParams) error
 	GetTaskByID(ctx context.Context, id int32) (Task, error)
+	ListTasks(ctx context.Context, arg ListTasksParams) ([]ListTasksRow, error)
+	ListTasksOverEstimate(ctx context.Context, arg ListTasksOverEstimateParams) ([]ListTasksOverEstimateRow, error)
+	SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) error
+	UpdateTaskTimes(ctx context.Context, arg UpdateTaskTimesParams) error
+	CountOverlappingTimeEntries(ctx context.Context, arg CountOverlappingTimeEntriesParams) (int64, error)
+	CountApprovedTimesheets(ctx context.Context, arg CountApprovedTimesheetsParams) (int64, error)
+	CountPeriodLocks(ctx context.Context, arg CountPeriodLocksParams) (int64, error)
+	CreateTaskAudit(ctx context.Context, arg CreateTaskAuditParams) error
+	GetCalendarEntriesByUserID(ctx context.Context, arg GetCalendarEntriesByUserIDParams) ([]GetCalendarEntriesByUserIDRow, error)
+	GetRunningTaskByUserID(ctx context.Context, userID int32) (Task, error)
+	CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) (int32, error)
+	CreateClosedTimeEntry(ctx context.Context, arg CreateClosedTimeEntryParams) (int32, error)
+	CloseTimeEntry(ctx context.Context, arg CloseTimeEntryParams) error
+	GetOpenTimeEntryByTaskID(ctx context.Context, taskID int32) (TimeEntry, error)
+	ListTimeEntriesByTaskID(ctx context.Context, taskID int32) ([]TimeEntry, error)
+	DeleteTimeEntriesByTaskID(ctx context.Context, taskID int32) error
+	GetPersonByID(ctx context.Context, id int32) (Person, error)
+	GetProjectByID(ctx context.Context, id int32) (Project, error)
+	UpsertTag(ctx context.Context, name string) (int32, error)
+	AddTaskTag(ctx context.Context, arg AddTaskTagParams) error
+	RemoveTaskTag(ctx context.Context, arg RemoveTaskTagParams) (int64, error)
+	ListTagsByTaskID(ctx context.Context, taskID int32) ([]string, error)
+	ListTags(ctx context.Context) ([]Tag, error)
+
+	// ExecTx runs fn against a repo bound to a single transaction,
+	// committing if fn returns nil and rolling back otherwise.
+	ExecTx(ctx context.Context, fn func(TasksRepo) error) error
+}
+
+type tasksRepo struct {
+	*Queries
+	db *sql.DB
 }
 
-func NewTasksRepo(db DBTX) TasksRepo {
-	return New(db)
+func NewTasksRepo(db *sql.DB) TasksRepo {
+	return &tasksRepo{
+		Queries: New(db),
+		db:      db,
+	}
+}
+
+func (r *tasksRepo) ExecTx(ctx context.Context, fn func(TasksRepo) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(&tasksRepo{
+		Queries: r.Queries.WithTx(tx),
+		db:      r.db,
+	})
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rollback err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
 }
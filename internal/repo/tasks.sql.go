@@ -11,52 +11,298 @@ import (
 	"time"
 )
 
+const countOverlappingTimeEntries = `-- name: CountOverlappingTimeEntries :one
+SELECT COUNT(*) FROM time_entries te
+JOIN tasks t ON t.id = te.task_id
+WHERE t.user_id = $1 AND
+t.id <> $2 AND
+t.deleted_at IS NULL AND
+te.start_dt < $3::timestamp AND
+COALESCE(te.end_dt, $4::timestamp) > $5::timestamp
+`
+
+type CountOverlappingTimeEntriesParams struct {
+	UserID  int32     `json:"user_id"`
+	TaskID  int32     `json:"task_id"`
+	EndDt   time.Time `json:"end_dt"`
+	Now     time.Time `json:"now"`
+	StartDt time.Time `json:"start_dt"`
+}
+
+func (q *Queries) CountOverlappingTimeEntries(ctx context.Context, arg CountOverlappingTimeEntriesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOverlappingTimeEntries,
+		arg.UserID,
+		arg.TaskID,
+		arg.EndDt,
+		arg.Now,
+		arg.StartDt,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createFinishedTask = `-- name: CreateFinishedTask :one
+INSERT INTO tasks (user_id, description, start_dt, end_dt, created_at, status, project_id) VALUES ($1, $2, $3, $4, $5, 'done', $6)
+RETURNING id
+`
+
+type CreateFinishedTaskParams struct {
+	UserID      int32         `json:"user_id"`
+	Description string        `json:"description"`
+	StartDt     sql.NullTime  `json:"start_dt"`
+	EndDt       sql.NullTime  `json:"end_dt"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ProjectID   sql.NullInt32 `json:"project_id"`
+}
+
+func (q *Queries) CreateFinishedTask(ctx context.Context, arg CreateFinishedTaskParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, createFinishedTask,
+		arg.UserID,
+		arg.Description,
+		arg.StartDt,
+		arg.EndDt,
+		arg.CreatedAt,
+		arg.ProjectID,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createTask = `-- name: CreateTask :one
-INSERT INTO tasks (user_id, description, created_at) VALUES ($1, $2, $3)
+INSERT INTO tasks (user_id, description, created_at, project_id) VALUES ($1, $2, $3, $4)
 RETURNING id
 `
 
 type CreateTaskParams struct {
-	UserID      int32     `json:"user_id"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+	UserID      int32         `json:"user_id"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ProjectID   sql.NullInt32 `json:"project_id"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (int32, error) {
-	row := q.db.QueryRowContext(ctx, createTask, arg.UserID, arg.Description, arg.CreatedAt)
+	row := q.db.QueryRowContext(ctx, createTask,
+		arg.UserID,
+		arg.Description,
+		arg.CreatedAt,
+		arg.ProjectID,
+	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
 
+const createTaskAudit = `-- name: CreateTaskAudit :exec
+INSERT INTO task_audit (task_id, changed_by, changed_at, note) VALUES ($1, $2, $3, $4)
+`
+
+type CreateTaskAuditParams struct {
+	TaskID    int32     `json:"task_id"`
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+	Note      string    `json:"note"`
+}
+
+func (q *Queries) CreateTaskAudit(ctx context.Context, arg CreateTaskAuditParams) error {
+	_, err := q.db.ExecContext(ctx, createTaskAudit,
+		arg.TaskID,
+		arg.ChangedBy,
+		arg.ChangedAt,
+		arg.Note,
+	)
+	return err
+}
+
+const getCalendarEntriesByUserID = `-- name: GetCalendarEntriesByUserID :many
+SELECT t.id AS task_id, t.description, t.status, t.start_dt AS task_start_dt, t.end_dt AS task_end_dt,
+    te.id AS entry_id, te.start_dt, te.end_dt
+FROM tasks t
+LEFT JOIN time_entries te ON te.task_id = t.id
+WHERE t.user_id = $1 AND
+t.deleted_at IS NULL AND
+COALESCE(te.start_dt, t.start_dt) < $2::timestamp AND
+COALESCE(te.end_dt, t.end_dt, $3::timestamp) > $4::timestamp
+ORDER BY COALESCE(te.start_dt, t.start_dt), t.id, te.id
+`
+
+type GetCalendarEntriesByUserIDParams struct {
+	UserID int32     `json:"user_id"`
+	ToDt   time.Time `json:"to_dt"`
+	Now    time.Time `json:"now"`
+	FromDt time.Time `json:"from_dt"`
+}
+
+type GetCalendarEntriesByUserIDRow struct {
+	TaskID      int32         `json:"task_id"`
+	Description string        `json:"description"`
+	Status      TaskStatus    `json:"status"`
+	TaskStartDt sql.NullTime  `json:"task_start_dt"`
+	TaskEndDt   sql.NullTime  `json:"task_end_dt"`
+	EntryID     sql.NullInt32 `json:"entry_id"`
+	StartDt     sql.NullTime  `json:"start_dt"`
+	EndDt       sql.NullTime  `json:"end_dt"`
+}
+
+func (q *Queries) GetCalendarEntriesByUserID(ctx context.Context, arg GetCalendarEntriesByUserIDParams) ([]GetCalendarEntriesByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCalendarEntriesByUserID,
+		arg.UserID,
+		arg.ToDt,
+		arg.Now,
+		arg.FromDt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCalendarEntriesByUserIDRow{}
+	for rows.Next() {
+		var i GetCalendarEntriesByUserIDRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.Description,
+			&i.Status,
+			&i.TaskStartDt,
+			&i.TaskEndDt,
+			&i.EntryID,
+			&i.StartDt,
+			&i.EndDt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClippedTasksByUserID = `-- name: GetClippedTasksByUserID :many
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.billable,
+    CAST(EXTRACT(EPOCH FROM SUM(
+        LEAST(COALESCE(te.end_dt, $1::timestamp), $2::timestamp) -
+        GREATEST(te.start_dt, $3::timestamp)
+    )) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.user_id = $4 AND
+t.deleted_at IS NULL AND
+t.status <> 'cancelled' AND
+te.start_dt < $2::timestamp AND
+COALESCE(te.end_dt, $1::timestamp) > $3::timestamp AND
+($5::text = '' OR EXISTS (
+    SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+    WHERE tt.task_id = t.id AND tg.name = $5
+))
+GROUP BY t.id ORDER BY total_seconds DESC, t.id
+`
+
+type GetClippedTasksByUserIDParams struct {
+	Now    time.Time `json:"now"`
+	ToDt   time.Time `json:"to_dt"`
+	FromDt time.Time `json:"from_dt"`
+	UserID int32     `json:"user_id"`
+	Tag    string    `json:"tag"`
+}
+
+type GetClippedTasksByUserIDRow struct {
+	ID           int32        `json:"id"`
+	UserID       int32        `json:"user_id"`
+	Description  string       `json:"description"`
+	StartDt      sql.NullTime `json:"start_dt"`
+	EndDt        sql.NullTime `json:"end_dt"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Status       TaskStatus   `json:"status"`
+	Billable     bool         `json:"billable"`
+	TotalSeconds int64        `json:"total_seconds"`
+}
+
+func (q *Queries) GetClippedTasksByUserID(ctx context.Context, arg GetClippedTasksByUserIDParams) ([]GetClippedTasksByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getClippedTasksByUserID,
+		arg.Now,
+		arg.ToDt,
+		arg.FromDt,
+		arg.UserID,
+		arg.Tag,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClippedTasksByUserIDRow{}
+	for rows.Next() {
+		var i GetClippedTasksByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Description,
+			&i.StartDt,
+			&i.EndDt,
+			&i.CreatedAt,
+			&i.Status,
+			&i.Billable,
+			&i.TotalSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOrderedTasksByUserID = `-- name: GetOrderedTasksByUserID :many
-SELECT id, user_id, description, start_dt, end_dt, created_at, CAST(EXTRACT(HOUR from end_dt - start_dt) AS INT) AS hours, 
-    CAST(EXTRACT(MINUTE from end_dt - start_dt) AS INT) as minutes  FROM tasks 
-WHERE user_id = $1 AND 
-start_dt >= $2 AND
-end_dt <=  $3 
-GROUP BY id ORDER BY hours DESC
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.billable,
+    CAST(EXTRACT(EPOCH FROM SUM(te.end_dt - te.start_dt)) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.user_id = $1 AND
+t.deleted_at IS NULL AND
+t.start_dt >= $2 AND
+t.end_dt <= $3 AND
+($4::text = '' OR EXISTS (
+    SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+    WHERE tt.task_id = t.id AND tg.name = $4
+))
+GROUP BY t.id ORDER BY total_seconds DESC, t.id
 `
 
 type GetOrderedTasksByUserIDParams struct {
 	UserID  int32        `json:"user_id"`
 	StartDt sql.NullTime `json:"start_dt"`
 	EndDt   sql.NullTime `json:"end_dt"`
+	Tag     string       `json:"tag"`
 }
 
 type GetOrderedTasksByUserIDRow struct {
-	ID          int32        `json:"id"`
-	UserID      int32        `json:"user_id"`
-	Description string       `json:"description"`
-	StartDt     sql.NullTime `json:"start_dt"`
-	EndDt       sql.NullTime `json:"end_dt"`
-	CreatedAt   time.Time    `json:"created_at"`
-	Hours       int32        `json:"hours"`
-	Minutes     int32        `json:"minutes"`
+	ID           int32        `json:"id"`
+	UserID       int32        `json:"user_id"`
+	Description  string       `json:"description"`
+	StartDt      sql.NullTime `json:"start_dt"`
+	EndDt        sql.NullTime `json:"end_dt"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Status       TaskStatus   `json:"status"`
+	Billable     bool         `json:"billable"`
+	TotalSeconds int64        `json:"total_seconds"`
 }
 
 func (q *Queries) GetOrderedTasksByUserID(ctx context.Context, arg GetOrderedTasksByUserIDParams) ([]GetOrderedTasksByUserIDRow, error) {
-	rows, err := q.db.QueryContext(ctx, getOrderedTasksByUserID, arg.UserID, arg.StartDt, arg.EndDt)
+	rows, err := q.db.QueryContext(ctx, getOrderedTasksByUserID,
+		arg.UserID,
+		arg.StartDt,
+		arg.EndDt,
+		arg.Tag,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +317,9 @@ func (q *Queries) GetOrderedTasksByUserID(ctx context.Context, arg GetOrderedTas
 			&i.StartDt,
 			&i.EndDt,
 			&i.CreatedAt,
-			&i.Hours,
-			&i.Minutes,
+			&i.Status,
+			&i.Billable,
+			&i.TotalSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -87,8 +334,31 @@ func (q *Queries) GetOrderedTasksByUserID(ctx context.Context, arg GetOrderedTas
 	return items, nil
 }
 
+const getRunningTaskByUserID = `-- name: GetRunningTaskByUserID :one
+SELECT id, user_id, description, start_dt, end_dt, created_at, status, deleted_at, project_id, billable, estimate_seconds FROM tasks WHERE user_id = $1 AND status = 'running' AND deleted_at IS NULL
+`
+
+func (q *Queries) GetRunningTaskByUserID(ctx context.Context, userID int32) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getRunningTaskByUserID, userID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Description,
+		&i.StartDt,
+		&i.EndDt,
+		&i.CreatedAt,
+		&i.Status,
+		&i.DeletedAt,
+		&i.ProjectID,
+		&i.Billable,
+		&i.EstimateSeconds,
+	)
+	return i, err
+}
+
 const getTaskByID = `-- name: GetTaskByID :one
-SELECT id, user_id, description, start_dt, end_dt, created_at FROM tasks WHERE id = $1
+SELECT id, user_id, description, start_dt, end_dt, created_at, status, deleted_at, project_id, billable, estimate_seconds FROM tasks WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetTaskByID(ctx context.Context, id int32) (Task, error) {
@@ -101,10 +371,112 @@ func (q *Queries) GetTaskByID(ctx context.Context, id int32) (Task, error) {
 		&i.StartDt,
 		&i.EndDt,
 		&i.CreatedAt,
+		&i.Status,
+		&i.DeletedAt,
+		&i.ProjectID,
+		&i.Billable,
+		&i.EstimateSeconds,
 	)
 	return i, err
 }
 
+const listTasks = `-- name: ListTasks :many
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.project_id, t.billable, t.estimate_seconds,
+    CAST(COALESCE(EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, $1::timestamp) - te.start_dt)), 0) AS BIGINT) AS total_seconds
+FROM tasks t
+LEFT JOIN time_entries te ON te.task_id = t.id
+WHERE t.deleted_at IS NULL AND
+    ($2::int = 0 OR t.user_id = $2) AND
+    ($3::int = 0 OR t.project_id = $3) AND
+    ($4::task_status IS NULL OR t.status = $4) AND
+    ($5::text = '' OR to_tsvector('simple', t.description) @@ plainto_tsquery('simple', $5)) AND
+    ($6::timestamp IS NULL OR t.created_at >= $6) AND
+    ($7::timestamp IS NULL OR t.created_at < $7) AND
+    (NOT $8::bool OR t.status = 'running') AND
+    ($9::text = '' OR EXISTS (
+        SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+        WHERE tt.task_id = t.id AND tg.name = $9
+    ))
+GROUP BY t.id
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT $10 OFFSET $11
+`
+
+type ListTasksParams struct {
+	Now         time.Time      `json:"now"`
+	UserID      int32          `json:"user_id"`
+	ProjectID   int32          `json:"project_id"`
+	Status      NullTaskStatus `json:"status"`
+	Query       string         `json:"query"`
+	CreatedFrom sql.NullTime   `json:"created_from"`
+	CreatedTo   sql.NullTime   `json:"created_to"`
+	RunningOnly bool           `json:"running_only"`
+	Tag         string         `json:"tag"`
+	Limit       sql.NullInt32  `json:"limit"`
+	Offset      int32          `json:"offset"`
+}
+
+type ListTasksRow struct {
+	ID              int32         `json:"id"`
+	UserID          int32         `json:"user_id"`
+	Description     string        `json:"description"`
+	StartDt         sql.NullTime  `json:"start_dt"`
+	EndDt           sql.NullTime  `json:"end_dt"`
+	CreatedAt       time.Time     `json:"created_at"`
+	Status          TaskStatus    `json:"status"`
+	ProjectID       sql.NullInt32 `json:"project_id"`
+	Billable        bool          `json:"billable"`
+	EstimateSeconds sql.NullInt64 `json:"estimate_seconds"`
+	TotalSeconds    int64         `json:"total_seconds"`
+}
+
+func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]ListTasksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTasks,
+		arg.Now,
+		arg.UserID,
+		arg.ProjectID,
+		arg.Status,
+		arg.Query,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.RunningOnly,
+		arg.Tag,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTasksRow{}
+	for rows.Next() {
+		var i ListTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Description,
+			&i.StartDt,
+			&i.EndDt,
+			&i.CreatedAt,
+			&i.Status,
+			&i.ProjectID,
+			&i.Billable,
+			&i.EstimateSeconds,
+			&i.TotalSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const setTaskEndDate = `-- name: SetTaskEndDate :exec
 UPDATE tasks SET end_dt = $1 WHERE id = $2
 `
@@ -132,3 +504,129 @@ func (q *Queries) SetTaskStartDate(ctx context.Context, arg SetTaskStartDatePara
 	_, err := q.db.ExecContext(ctx, setTaskStartDate, arg.StartDt, arg.ID)
 	return err
 }
+
+const setTaskStatus = `-- name: SetTaskStatus :exec
+UPDATE tasks SET status = $1 WHERE id = $2
+`
+
+type SetTaskStatusParams struct {
+	Status TaskStatus `json:"status"`
+	ID     int32      `json:"id"`
+}
+
+func (q *Queries) SetTaskStatus(ctx context.Context, arg SetTaskStatusParams) error {
+	_, err := q.db.ExecContext(ctx, setTaskStatus, arg.Status, arg.ID)
+	return err
+}
+
+const listTasksOverEstimate = `-- name: ListTasksOverEstimate :many
+SELECT t.id, t.user_id, t.description, t.start_dt, t.end_dt, t.created_at, t.status, t.project_id, t.billable, t.estimate_seconds,
+    CAST(EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, $1::timestamp) - te.start_dt)) AS BIGINT) AS total_seconds
+FROM tasks t
+JOIN time_entries te ON te.task_id = t.id
+WHERE t.deleted_at IS NULL AND
+    t.estimate_seconds IS NOT NULL AND
+    ($2::int = 0 OR t.user_id = $2) AND
+    ($3::int = 0 OR t.project_id = $3)
+GROUP BY t.id
+HAVING EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, $1::timestamp) - te.start_dt)) > t.estimate_seconds
+ORDER BY EXTRACT(EPOCH FROM SUM(COALESCE(te.end_dt, $1::timestamp) - te.start_dt)) - t.estimate_seconds DESC, t.id
+`
+
+type ListTasksOverEstimateParams struct {
+	Now       time.Time `json:"now"`
+	UserID    int32     `json:"user_id"`
+	ProjectID int32     `json:"project_id"`
+}
+
+type ListTasksOverEstimateRow struct {
+	ID              int32         `json:"id"`
+	UserID          int32         `json:"user_id"`
+	Description     string        `json:"description"`
+	StartDt         sql.NullTime  `json:"start_dt"`
+	EndDt           sql.NullTime  `json:"end_dt"`
+	CreatedAt       time.Time     `json:"created_at"`
+	Status          TaskStatus    `json:"status"`
+	ProjectID       sql.NullInt32 `json:"project_id"`
+	Billable        bool          `json:"billable"`
+	EstimateSeconds sql.NullInt64 `json:"estimate_seconds"`
+	TotalSeconds    int64         `json:"total_seconds"`
+}
+
+func (q *Queries) ListTasksOverEstimate(ctx context.Context, arg ListTasksOverEstimateParams) ([]ListTasksOverEstimateRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTasksOverEstimate, arg.Now, arg.UserID, arg.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTasksOverEstimateRow{}
+	for rows.Next() {
+		var i ListTasksOverEstimateRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Description,
+			&i.StartDt,
+			&i.EndDt,
+			&i.CreatedAt,
+			&i.Status,
+			&i.ProjectID,
+			&i.Billable,
+			&i.EstimateSeconds,
+			&i.TotalSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteTask = `-- name: SoftDeleteTask :exec
+UPDATE tasks SET deleted_at = $1 WHERE id = $2
+`
+
+type SoftDeleteTaskParams struct {
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	ID        int32        `json:"id"`
+}
+
+func (q *Queries) SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) error {
+	_, err := q.db.ExecContext(ctx, softDeleteTask, arg.DeletedAt, arg.ID)
+	return err
+}
+
+const updateTaskTimes = `-- name: UpdateTaskTimes :exec
+UPDATE tasks SET description = $2, start_dt = $3, end_dt = $4, status = $5, project_id = $6, billable = $7, estimate_seconds = $8 WHERE id = $1
+`
+
+type UpdateTaskTimesParams struct {
+	ID              int32         `json:"id"`
+	Description     string        `json:"description"`
+	StartDt         sql.NullTime  `json:"start_dt"`
+	EndDt           sql.NullTime  `json:"end_dt"`
+	Status          TaskStatus    `json:"status"`
+	ProjectID       sql.NullInt32 `json:"project_id"`
+	Billable        bool          `json:"billable"`
+	EstimateSeconds sql.NullInt64 `json:"estimate_seconds"`
+}
+
+func (q *Queries) UpdateTaskTimes(ctx context.Context, arg UpdateTaskTimesParams) error {
+	_, err := q.db.ExecContext(ctx, updateTaskTimes,
+		arg.ID,
+		arg.Description,
+		arg.StartDt,
+		arg.EndDt,
+		arg.Status,
+		arg.ProjectID,
+		arg.Billable,
+		arg.EstimateSeconds,
+	)
+	return err
+}
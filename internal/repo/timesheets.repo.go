@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+)
+
+type TimesheetsRepo interface {
+	CreateTimesheet(ctx context.Context, arg CreateTimesheetParams) (int32, error)
+	GetTimesheetByID(ctx context.Context, id int32) (Timesheet, error)
+	ListTimesheets(ctx context.Context, arg ListTimesheetsParams) ([]Timesheet, error)
+	SetTimesheetStatus(ctx context.Context, arg SetTimesheetStatusParams) (int64, error)
+	GetClippedTasksByUserID(ctx context.Context, arg GetClippedTasksByUserIDParams) ([]GetClippedTasksByUserIDRow, error)
+	GetPersonByID(ctx context.Context, id int32) (Person, error)
+}
+
+func NewTimesheetsRepo(db DBTX) TimesheetsRepo {
+	return New(db)
+}
@@ -8,7 +8,7 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewRouter(peopleCntrl *controller.PeopleController, taskCntrl *controller.TasksController, l *zap.Logger) *gin.Engine {
+func NewRouter(peopleCntrl *controller.PeopleController, taskCntrl *controller.TasksController, reportsCntrl *controller.ReportsController, exportCntrl *controller.ExportController, importCntrl *controller.ImportController, projectsCntrl *controller.ProjectsController, ratesCntrl *controller.RatesController, invoicesCntrl *controller.InvoicesController, timesheetsCntrl *controller.TimesheetsController, locksCntrl *controller.LocksController, l *zap.Logger) *gin.Engine {
 	router := gin.New()
 	router.Use(RequestLogger(l))
 	people := router.Group("/people")
@@ -17,6 +17,11 @@ func NewRouter(peopleCntrl *controller.PeopleController, taskCntrl *controller.T
 		people.GET("/list", peopleCntrl.List)
 		people.PUT("/update", peopleCntrl.Update)
 		people.DELETE("/delete", peopleCntrl.Delete)
+		people.GET("/:id/current-task", taskCntrl.Current)
+		people.GET("/:id/calendar.ics", taskCntrl.Calendar)
+		people.GET("/:id/schedule", peopleCntrl.Schedule)
+		people.PUT("/:id/schedule", peopleCntrl.UpdateSchedule)
+		people.POST("/:id/enrich", peopleCntrl.Enrich)
 	}
 
 	tasks := router.Group("/tasks")
@@ -24,7 +29,87 @@ func NewRouter(peopleCntrl *controller.PeopleController, taskCntrl *controller.T
 		tasks.POST("/create", taskCntrl.Create)
 		tasks.POST("/start", taskCntrl.Start)
 		tasks.POST("/update", taskCntrl.End)
+		tasks.POST("/pause", taskCntrl.Pause)
+		tasks.POST("/resume", taskCntrl.Resume)
+		tasks.POST("/cancel", taskCntrl.Cancel)
 		tasks.GET("/ordered", taskCntrl.Ordered)
+		tasks.GET("/over-estimate", taskCntrl.OverEstimate)
+		tasks.POST("/manual", taskCntrl.Manual)
+		tasks.GET("", taskCntrl.List)
+		tasks.GET("/:id", taskCntrl.Get)
+		tasks.PUT("/:id", taskCntrl.Update)
+		tasks.DELETE("/:id", taskCntrl.Delete)
+		tasks.POST("/:id/tags", taskCntrl.AddTag)
+		tasks.DELETE("/:id/tags/:tag", taskCntrl.RemoveTag)
+		tasks.POST("/import", importCntrl.Import)
+	}
+
+	router.GET("/tags", taskCntrl.ListTags)
+
+	reports := router.Group("/reports")
+	{
+		reports.GET("/people/:id", reportsCntrl.Person)
+		reports.GET("/people/:id/overtime", reportsCntrl.Overtime)
+		reports.GET("/team", reportsCntrl.Team)
+		reports.GET("/projects", reportsCntrl.Projects)
+		reports.GET("/projects/:id/burndown", reportsCntrl.Burndown)
+		reports.GET("/tags", reportsCntrl.Tags)
+	}
+
+	clients := router.Group("/clients")
+	{
+		clients.POST("", projectsCntrl.CreateClient)
+		clients.GET("", projectsCntrl.ListClients)
+		clients.GET("/:id", projectsCntrl.GetClient)
+		clients.PUT("/:id", projectsCntrl.UpdateClient)
+		clients.DELETE("/:id", projectsCntrl.DeleteClient)
+	}
+
+	projects := router.Group("/projects")
+	{
+		projects.POST("", projectsCntrl.CreateProject)
+		projects.GET("", projectsCntrl.ListProjects)
+		projects.GET("/:id", projectsCntrl.GetProject)
+		projects.PUT("/:id", projectsCntrl.UpdateProject)
+		projects.DELETE("/:id", projectsCntrl.DeleteProject)
+	}
+
+	rates := router.Group("/rates")
+	{
+		rates.POST("", ratesCntrl.Create)
+		rates.GET("", ratesCntrl.List)
+		rates.DELETE("/:id", ratesCntrl.Delete)
+	}
+
+	invoices := router.Group("/invoices")
+	{
+		invoices.POST("", invoicesCntrl.Create)
+		invoices.GET("", invoicesCntrl.List)
+		invoices.GET("/:id", invoicesCntrl.Get)
+	}
+
+	timesheets := router.Group("/timesheets")
+	{
+		timesheets.POST("", timesheetsCntrl.Create)
+		timesheets.GET("", timesheetsCntrl.List)
+		timesheets.GET("/:id", timesheetsCntrl.Get)
+		timesheets.POST("/:id/submit", timesheetsCntrl.Submit)
+		timesheets.POST("/:id/approve", timesheetsCntrl.Approve)
+		timesheets.POST("/:id/reject", timesheetsCntrl.Reject)
+	}
+
+	admin := router.Group("/admin")
+	{
+		admin.POST("/locks", locksCntrl.Create)
+		admin.GET("/locks", locksCntrl.List)
+		admin.DELETE("/locks/:id", locksCntrl.Delete)
+	}
+
+	export := router.Group("/export")
+	{
+		export.GET("/tasks", exportCntrl.Tasks)
+		export.GET("/reports/people/:id", exportCntrl.PersonReport)
+		export.GET("/reports/team", exportCntrl.TeamReport)
 	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
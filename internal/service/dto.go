@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -9,6 +10,44 @@ var ErrAlreadyExists = errors.New("person already exists")
 var ErrApiInternal = errors.New("third api internal error")
 var ErrNoResult = errors.New("record not found")
 var ErrBadRequest = errors.New("third api bad request")
+var ErrApiNotFound = errors.New("third api has no person with the passport")
+var ErrApiThrottled = errors.New("third api throttled the request")
+var ErrApiTimeout = errors.New("third api timed out")
+var ErrApiUnavailable = errors.New("third api is unavailable, try again later")
+var ErrInvalidEnrichmentStatus = errors.New("enrichment_status must be one of pending, enriched, failed")
+var ErrInvalidPeriod = errors.New("period must be one of day, week, month")
+var ErrInvalidRange = errors.New("to_dt must be after from_dt")
+var ErrInvalidSort = errors.New("unsupported sort field")
+var ErrInvalidInterval = errors.New("start_dt must be before end_dt")
+var ErrEndInFuture = errors.New("end_dt is in the future")
+var ErrOverlap = errors.New("time overlaps another task of the person")
+var ErrInvalidStatus = errors.New("status must be one of created, running, paused, done, cancelled")
+var ErrEmptyTag = errors.New("tag must not be empty")
+var ErrInvalidAmount = errors.New("amount must be a non-negative decimal with at most 2 fraction digits")
+var ErrInvalidRateTarget = errors.New("exactly one of user_id, project_id, task_id must be set")
+var ErrInvalidTaxRate = errors.New("tax_rate must be a percentage between 0 and 100 with at most 2 fraction digits")
+var ErrNothingToInvoice = errors.New("no billable time for the client in the range")
+var ErrAlreadyInvoiced = errors.New("range overlaps another invoice of the client")
+var ErrInvalidEstimate = errors.New("estimate must not be negative")
+var ErrInvalidBudget = errors.New("budget must not be negative")
+var ErrNoBudget = errors.New("project has no budget")
+var ErrTimesheetExists = errors.New("person already has a timesheet for the week")
+var ErrInvalidTimesheetStatus = errors.New("status must be one of draft, submitted, approved, rejected")
+var ErrInvalidTimesheetTransition = errors.New("invalid timesheet status transition")
+var ErrEmptyComment = errors.New("comment must not be empty")
+var ErrPeriodLocked = errors.New("time falls into a locked period")
+var ErrInvalidSchedule = errors.New("weekly hours must not be negative and work_start must be before work_end")
+var ErrInvalidWorkDay = errors.New("work days must be among mon, tue, wed, thu, fri, sat, sun")
+var ErrInvalidClock = errors.New("time of day must be formatted as HH:MM")
+var ErrInvalidBasis = errors.New("basis must be one of day, week")
+
+// ErrInvalidTransition is wrapped by every task lifecycle error.
+var ErrInvalidTransition = errors.New("invalid task status transition")
+var ErrTaskNotStarted = fmt.Errorf("%w: task has not been started", ErrInvalidTransition)
+var ErrTaskAlreadyRunning = fmt.Errorf("%w: task is already running", ErrInvalidTransition)
+var ErrTaskNotRunning = fmt.Errorf("%w: task is not running", ErrInvalidTransition)
+var ErrTaskFinished = fmt.Errorf("%w: task is already finished", ErrInvalidTransition)
+var ErrAnotherTaskRunning = fmt.Errorf("%w: person already has a running task", ErrInvalidTransition)
 
 type Person struct {
 	ID             int32  `json:"id"`
@@ -18,6 +57,11 @@ type Person struct {
 	Surname        string `json:"surname"`
 	Patronymic     string `json:"patronymic,omitempty"`
 	Address        string `json:"address"`
+	// EnrichmentStatus is pending until the name and address have been fetched from
+	// the people info API, then enriched, or failed. EnrichmentError tells why the
+	// last lookup failed.
+	EnrichmentStatus string `json:"enrichment_status,omitempty"`
+	EnrichmentError  string `json:"enrichment_error,omitempty"`
 }
 
 type Filter struct {
@@ -29,6 +73,22 @@ type Filter struct {
 	Name           string `json:"name"`
 	Patronymic     string `json:"patronymic"`
 	Address        string `json:"address"`
+	// EnrichmentStatus is one of pending, enriched, failed.
+	EnrichmentStatus string `json:"enrichment_status"`
+}
+
+// TaskFilter selects tasks for ListTasks; zero values don't filter.
+type TaskFilter struct {
+	Limit       *int32     `json:"limit"`
+	Offset      *int32     `json:"offset"`
+	UserID      int32      `json:"user_id"`
+	ProjectID   int32      `json:"project_id"`
+	Status      string     `json:"status"`
+	Query       string     `json:"query"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+	RunningOnly bool       `json:"running_only"`
+	Tag         string     `json:"tag"`
 }
 
 type UpdatedPerson struct {
@@ -48,7 +108,323 @@ type Task struct {
 	StartDt     time.Time `json:"start_dt,omitempty"`
 	EndDt       time.Time `json:"end_dt,omitempty"`
 	CreatedAt   time.Time `json:"created_at,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	ProjectID   int32     `json:"project_id,omitempty"`
+	Billable    bool      `json:"billable"`
+	Tags        []string  `json:"tags,omitempty"`
+
+	// TotalSeconds is the tracked time summed over all work sessions,
+	// Duration is the same value as an ISO-8601 duration.
+	TotalSeconds int64  `json:"total_seconds"`
+	Duration     string `json:"duration,omitempty"`
+	Hours        int    `json:"hours,omitempty"`
+	Minutes      int    `json:"minutes,omitempty"`
+
+	// EstimateSeconds is set only for estimated tasks; the tracked time is then split
+	// into what is left of the estimate and how far it has been exceeded.
+	EstimateSeconds  int64  `json:"estimate_seconds,omitempty"`
+	Estimate         string `json:"estimate,omitempty"`
+	RemainingSeconds int64  `json:"remaining_seconds,omitempty"`
+	OverrunSeconds   int64  `json:"overrun_seconds,omitempty"`
+}
+
+// CurrentTask is the running task of a person with the time tracked on it so far,
+// including the session that is still open.
+type CurrentTask struct {
+	Task
+	SessionStartDt time.Time `json:"session_start_dt"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"`
+}
+
+// Reports carry the billable amount of the tracked time both in cents and as a decimal
+// string. Time on non-billable tasks or without a rate in effect is billed at zero.
+type PersonReport struct {
+	UserID       int32          `json:"user_id"`
+	Period       string         `json:"period"`
+	FromDt       time.Time      `json:"from_dt"`
+	ToDt         time.Time      `json:"to_dt"`
+	TotalSeconds int64          `json:"total_seconds"`
+	Duration     string         `json:"duration"`
+	AmountCents  int64          `json:"amount_cents"`
+	Amount       string         `json:"amount"`
+	Buckets      []ReportBucket `json:"buckets"`
+}
+
+type ReportBucket struct {
+	Start        time.Time    `json:"start"`
+	End          time.Time    `json:"end"`
+	TotalSeconds int64        `json:"total_seconds"`
+	Duration     string       `json:"duration"`
+	AmountCents  int64        `json:"amount_cents"`
+	Amount       string       `json:"amount"`
+	Tasks        []ReportTask `json:"tasks"`
+}
+
+type ReportTask struct {
+	TaskID       int32  `json:"task_id"`
+	Description  string `json:"description"`
+	TotalSeconds int64  `json:"total_seconds"`
+	Duration     string `json:"duration"`
+	AmountCents  int64  `json:"amount_cents"`
+	Amount       string `json:"amount"`
+}
+
+// PersonWorkload is a person's row of the team report.
+type PersonWorkload struct {
+	Person
+	TaskCount          int32  `json:"task_count"`
+	TotalSeconds       int64  `json:"total_seconds"`
+	Duration           string `json:"duration"`
+	LongestTaskID      int32  `json:"longest_task_id,omitempty"`
+	LongestTaskSeconds int64  `json:"longest_task_seconds"`
+	AverageTaskSeconds int64  `json:"average_task_seconds"`
+	AmountCents        int64  `json:"amount_cents"`
+	Amount             string `json:"amount"`
+}
+
+// ClientReport is the time spent on the projects of a client; tasks without
+// a project and projects without a client are reported under ID 0.
+type ClientReport struct {
+	ClientID     int32           `json:"client_id"`
+	ClientName   string          `json:"client_name,omitempty"`
+	TotalSeconds int64           `json:"total_seconds"`
+	Duration     string          `json:"duration"`
+	AmountCents  int64           `json:"amount_cents"`
+	Amount       string          `json:"amount"`
+	Projects     []ProjectReport `json:"projects"`
+}
+
+type ProjectReport struct {
+	ProjectID    int32  `json:"project_id"`
+	ProjectName  string `json:"project_name,omitempty"`
+	TaskCount    int32  `json:"task_count"`
+	TotalSeconds int64  `json:"total_seconds"`
+	Duration     string `json:"duration"`
+	AmountCents  int64  `json:"amount_cents"`
+	Amount       string `json:"amount"`
+}
+
+// TagReport is the time a person spent on tasks of each tag. A task with several
+// tags counts towards each of them, so the tag totals may add up to more than the tracked time.
+type TagReport struct {
+	Person
+	Tags []TagTotal `json:"tags"`
+}
+
+type TagTotal struct {
+	Tag          string `json:"tag"`
+	TaskCount    int32  `json:"task_count"`
+	TotalSeconds int64  `json:"total_seconds"`
+	Duration     string `json:"duration"`
+	AmountCents  int64  `json:"amount_cents"`
+	Amount       string `json:"amount"`
+}
+
+type ImportReport struct {
+	DryRun   bool            `json:"dry_run"`
+	Total    int             `json:"total"`
+	Imported int             `json:"imported"`
+	Failed   []ImportFailure `json:"failed"`
+}
+
+type ImportFailure struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// TaskEdit changes the description, project, billable flag, estimate and/or the times of a task;
+// nil fields are kept, a zero ProjectID detaches the task from its project and a zero
+// EstimateSeconds removes the estimate.
+type TaskEdit struct {
+	ID              int32      `json:"id"`
+	Description     *string    `json:"description"`
+	ProjectID       *int32     `json:"project_id"`
+	Billable        *bool      `json:"billable"`
+	EstimateSeconds *int64     `json:"estimate_seconds"`
+	StartDt         *time.Time `json:"start_dt"`
+	EndDt           *time.Time `json:"end_dt"`
+	ChangedBy       string     `json:"changed_by"`
+}
+
+// ManualTask is a finished task entered after the fact.
+type ManualTask struct {
+	UserID      int32     `json:"user_id"`
+	ProjectID   int32     `json:"project_id"`
+	Description string    `json:"description"`
+	StartDt     time.Time `json:"start_dt"`
+	EndDt       time.Time `json:"end_dt"`
+	ChangedBy   string    `json:"changed_by"`
+}
+
+type Client struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Project struct {
+	ID            int32     `json:"id"`
+	ClientID      int32     `json:"client_id,omitempty"`
+	Name          string    `json:"name"`
+	BudgetSeconds int64     `json:"budget_seconds,omitempty"`
+	Budget        string    `json:"budget,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// UpdatedProject changes a project; nil fields are kept, a zero ClientID
+// detaches the project from its client and a zero BudgetSeconds removes the budget.
+type UpdatedProject struct {
+	ID            int32   `json:"id"`
+	ClientID      *int32  `json:"client_id"`
+	Name          *string `json:"name"`
+	BudgetSeconds *int64  `json:"budget_seconds"`
+}
+
+// ProjectBurndown is the budget of a project drawn down by the time tracked on it,
+// period by period. Spent time includes everything tracked before the first period,
+// so Remaining goes negative once the budget is overrun.
+type ProjectBurndown struct {
+	ProjectID     int32           `json:"project_id"`
+	Period        string          `json:"period"`
+	FromDt        time.Time       `json:"from_dt"`
+	ToDt          time.Time       `json:"to_dt"`
+	BudgetSeconds int64           `json:"budget_seconds"`
+	Budget        string          `json:"budget"`
+	Points        []BurndownPoint `json:"points"`
+}
+
+type BurndownPoint struct {
+	Start            time.Time `json:"start"`
+	End              time.Time `json:"end"`
+	TrackedSeconds   int64     `json:"tracked_seconds"`
+	SpentSeconds     int64     `json:"spent_seconds"`
+	RemainingSeconds int64     `json:"remaining_seconds"`
+	Remaining        string    `json:"remaining"`
+}
+
+// Rate is an hourly rate of a person, a project or a task, in effect from EffectiveFrom
+// until the next rate of the same person, project or task.
+type Rate struct {
+	ID            int32     `json:"id"`
+	UserID        int32     `json:"user_id,omitempty"`
+	ProjectID     int32     `json:"project_id,omitempty"`
+	TaskID        int32     `json:"task_id,omitempty"`
+	Amount        string    `json:"amount"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// PeriodLock closes [FromDt, ToDt) for one person, or for everyone when UserID is 0:
+// no task time inside it can be created or changed until the lock is removed.
+type PeriodLock struct {
+	ID        int32     `json:"id"`
+	UserID    int32     `json:"user_id,omitempty"`
+	FromDt    time.Time `json:"from_dt"`
+	ToDt      time.Time `json:"to_dt"`
+	LockedBy  string    `json:"locked_by"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Invoice is a snapshot of the billable time of a client over [FromDt, ToDt): it keeps the
+// client, project and person names and the rates as they were when it was created.
+type Invoice struct {
+	ID            int32         `json:"id"`
+	Number        string        `json:"number"`
+	ClientID      int32         `json:"client_id,omitempty"`
+	ClientName    string        `json:"client_name"`
+	ClientAddress string        `json:"client_address,omitempty"`
+	FromDt        time.Time     `json:"from_dt"`
+	ToDt          time.Time     `json:"to_dt"`
+	TaxRate       string        `json:"tax_rate"`
+	Subtotal      string        `json:"subtotal"`
+	Tax           string        `json:"tax"`
+	Total         string        `json:"total"`
+	CreatedAt     time.Time     `json:"created_at"`
+	Lines         []InvoiceLine `json:"lines,omitempty"`
+}
+
+// InvoiceLine is the time of a person on a project billed at one rate. Hours are rounded
+// for display, Amount is computed from the exact seconds.
+type InvoiceLine struct {
+	ProjectID   int32  `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	UserID      int32  `json:"user_id"`
+	PersonName  string `json:"person_name"`
+	Rate        string `json:"rate"`
+	Seconds     int64  `json:"seconds"`
+	Hours       string `json:"hours"`
+	Amount      string `json:"amount"`
+}
+
+// Timesheet is a person's week, Monday to Monday, submitted for approval. Once approved,
+// the time tracked within the week can no longer be changed. The tasks of the week and
+// their total are only filled in for a single timesheet, not in listings.
+type Timesheet struct {
+	ID           int32     `json:"id"`
+	UserID       int32     `json:"user_id"`
+	WeekStart    time.Time `json:"week_start"`
+	WeekEnd      time.Time `json:"week_end"`
+	Status       string    `json:"status"`
+	Comment      string    `json:"comment,omitempty"`
+	SubmittedAt  time.Time `json:"submitted_at,omitempty"`
+	ReviewedBy   string    `json:"reviewed_by,omitempty"`
+	ReviewedAt   time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	TotalSeconds int64     `json:"total_seconds,omitempty"`
+	Duration     string    `json:"duration,omitempty"`
+	Tasks        []Task    `json:"tasks,omitempty"`
+}
+
+// Schedule is the contracted working time of a person: the weekly hours, spread evenly
+// over the work days, and the working hours of a day formatted as HH:MM.
+type Schedule struct {
+	UserID        int32    `json:"user_id"`
+	WeeklySeconds int64    `json:"weekly_seconds"`
+	Weekly        string   `json:"weekly"`
+	WorkDays      []string `json:"work_days"`
+	WorkStart     string   `json:"work_start"`
+	WorkEnd       string   `json:"work_end"`
+}
+
+// OvertimeRules configure how the overtime report classifies tracked time. Night hours run
+// from NightStart to NightEnd and may wrap around midnight. Basis tells whether the contracted
+// hours are a daily or a weekly allowance, and with OutsideHours set any time worked on a work
+// day outside the working hours counts as overtime right away.
+type OvertimeRules struct {
+	NightStart   string `json:"night_start"`
+	NightEnd     string `json:"night_end"`
+	Basis        string `json:"basis"`
+	OutsideHours bool   `json:"outside_hours"`
+}
+
+// OvertimeTotals split tracked time into regular, overtime, weekend and night seconds,
+// which always add up to TrackedSeconds. BalanceSeconds is tracked minus expected time.
+type OvertimeTotals struct {
+	ExpectedSeconds int64 `json:"expected_seconds"`
+	TrackedSeconds  int64 `json:"tracked_seconds"`
+	RegularSeconds  int64 `json:"regular_seconds"`
+	OvertimeSeconds int64 `json:"overtime_seconds"`
+	WeekendSeconds  int64 `json:"weekend_seconds"`
+	NightSeconds    int64 `json:"night_seconds"`
+	BalanceSeconds  int64 `json:"balance_seconds"`
+}
+
+type OvertimeBucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	OvertimeTotals
+}
 
-	Hours   int `json:"hours,omitempty"`
-	Minutes int `json:"minutes,omitempty"`
+// OvertimeReport compares the time tracked by a person with their schedule, period by period.
+type OvertimeReport struct {
+	UserID   int32            `json:"user_id"`
+	Period   string           `json:"period"`
+	FromDt   time.Time        `json:"from_dt"`
+	ToDt     time.Time        `json:"to_dt"`
+	Schedule Schedule         `json:"schedule"`
+	Rules    OvertimeRules    `json:"rules"`
+	Buckets  []OvertimeBucket `json:"buckets"`
+	OvertimeTotals
 }
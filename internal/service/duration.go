@@ -0,0 +1,58 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// setDuration fills every duration field of the task from the total tracked seconds,
+// so that hours, minutes and the ISO-8601 string always agree with each other.
+func (t *Task) setDuration(totalSeconds int64) {
+	t.TotalSeconds = totalSeconds
+	t.Hours = int(totalSeconds / 3600)
+	t.Minutes = int(totalSeconds % 3600 / 60)
+	t.Duration = isoDuration(totalSeconds)
+}
+
+// setEstimate fills the estimate fields of the task and compares the estimate
+// with the tracked time, so it must be called after setDuration.
+func (t *Task) setEstimate(estimate sql.NullInt64) {
+	if !estimate.Valid {
+		return
+	}
+	t.EstimateSeconds = estimate.Int64
+	t.Estimate = isoDuration(estimate.Int64)
+	if t.TotalSeconds < estimate.Int64 {
+		t.RemainingSeconds = estimate.Int64 - t.TotalSeconds
+	} else {
+		t.OverrunSeconds = t.TotalSeconds - estimate.Int64
+	}
+}
+
+// isoDuration formats seconds as an ISO-8601 duration using hours as the largest
+// unit (e.g. PT26H0M5S), since days are not a fixed amount of time.
+func isoDuration(totalSeconds int64) string {
+	if totalSeconds == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	if totalSeconds < 0 {
+		b.WriteByte('-')
+		totalSeconds = -totalSeconds
+	}
+	b.WriteString("PT")
+
+	hours := totalSeconds / 3600
+	minutes := totalSeconds % 3600 / 60
+	seconds := totalSeconds % 60
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if hours > 0 || minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	fmt.Fprintf(&b, "%dS", seconds)
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package service
+
+import "testing"
+
+func TestIsoDuration(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		want    string
+	}{
+		{seconds: 0, want: "PT0S"},
+		{seconds: 5, want: "PT5S"},
+		{seconds: 60, want: "PT1M0S"},
+		{seconds: 3600, want: "PT1H0M0S"},
+		{seconds: 26*3600 + 5, want: "PT26H0M5S"},
+		{seconds: 90061, want: "PT25H1M1S"},
+		{seconds: -3605, want: "-PT1H0M5S"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := isoDuration(tt.seconds); got != tt.want {
+				t.Errorf("isoDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+			}
+		})
+	}
+}
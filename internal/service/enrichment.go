@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gogoalish/timetracker/config"
+	"github.com/gogoalish/timetracker/internal/logger"
+	"github.com/gogoalish/timetracker/internal/repo"
+	"go.uber.org/zap"
+)
+
+// EnrichmentWorker fills in the name and address of people created with only a passport
+// by looking them up in the people info API. Fields edited in the meantime are kept. Jobs live in the enrichment_jobs table, so
+// they survive restarts and several instances can share them.
+type EnrichmentWorker struct {
+	repo repo.EnrichmentRepo
+	api  ApiClient
+	cfg  config.EnrichmentConfig
+}
+
+func NewEnrichmentWorker(repo repo.EnrichmentRepo, api ApiClient, cfg config.EnrichmentConfig) *EnrichmentWorker {
+	return &EnrichmentWorker{
+		repo: repo,
+		api:  api,
+		cfg:  cfg,
+	}
+}
+
+// Run processes due jobs every Interval until ctx is cancelled. The logger is taken from ctx.
+func (w *EnrichmentWorker) Run(ctx context.Context) {
+	l, ok := logger.FromContext(ctx)
+	if !ok {
+		l = zap.NewNop()
+	}
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := w.RunOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				l.Error("EnrichmentWorker - Run - RunOnce error", zap.Error(err))
+			}
+			// a full batch means more jobs may be due already
+			if err != nil || n == 0 || n < w.cfg.Batch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce claims up to Batch due jobs and processes them, returning how many were claimed.
+func (w *EnrichmentWorker) RunOnce(ctx context.Context) (int, error) {
+	now := time.Now()
+	jobs, err := w.repo.ClaimEnrichmentJobs(ctx, repo.ClaimEnrichmentJobsParams{
+		LockedUntil: now.Add(w.cfg.Lease),
+		Now:         now,
+		Batch:       int32(w.cfg.Batch),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		if err := w.process(ctx, job); err != nil {
+			return len(jobs), err
+		}
+	}
+	return len(jobs), nil
+}
+
+func (w *EnrichmentWorker) process(ctx context.Context, job repo.EnrichmentJob) error {
+	l, ok := logger.FromContext(ctx)
+	if !ok {
+		l = zap.NewNop()
+	}
+
+	// the claim leased the whole batch, so the lease is renewed for every job; if it ran out
+	// during the earlier jobs of the batch, another worker may have taken this one
+	renewed, err := w.repo.RenewEnrichmentJobLease(ctx, repo.RenewEnrichmentJobLeaseParams{
+		LockedUntil:  time.Now().Add(w.cfg.Lease),
+		ID:           job.ID,
+		ClaimedUntil: job.LockedUntil.Time,
+	})
+	if err != nil {
+		return err
+	}
+	if renewed == 0 {
+		l.Debug("Person enrichment job taken over", zap.Int32("id", job.PersonID))
+		return nil
+	}
+
+	person, err := w.repo.GetPersonByID(ctx, job.PersonID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return w.repo.DeleteEnrichmentJob(ctx, job.ID)
+		}
+		return err
+	}
+
+	info, apiErr := w.api.InfoGet(ctx, person.PassportSerie, person.PassportNumber)
+	if ctx.Err() != nil {
+		// shutting down, the job is picked up again once its lease runs out
+		return ctx.Err()
+	}
+	if apiErr == nil {
+		l.Info("Person enriched", zap.Int32("id", person.ID), zap.Int32("attempts", job.Attempts))
+		return w.repo.ExecTx(ctx, func(r repo.EnrichmentRepo) error {
+			err := r.EnrichPerson(ctx, repo.EnrichPersonParams{
+				ID:      person.ID,
+				Name:    info.Name,
+				Surname: info.Surname,
+				Patronymic: sql.NullString{
+					String: info.Patronymic,
+					Valid:  info.Patronymic != "",
+				},
+				Address: info.Address,
+			})
+			if err != nil {
+				return err
+			}
+			return r.DeleteEnrichmentJob(ctx, job.ID)
+		})
+	}
+
+	// the API has answered for good, asking again would get the same answer
+	final := errors.Is(apiErr, ErrApiNotFound) || errors.Is(apiErr, ErrBadRequest)
+	if final || int(job.Attempts) >= w.cfg.MaxAttempts {
+		l.Warn("Person enrichment failed", zap.Int32("id", person.ID), zap.Int32("attempts", job.Attempts), zap.Error(apiErr))
+		return w.repo.ExecTx(ctx, func(r repo.EnrichmentRepo) error {
+			err := r.SetPersonEnrichmentStatus(ctx, repo.SetPersonEnrichmentStatusParams{
+				ID:               person.ID,
+				EnrichmentStatus: repo.EnrichmentStatusFailed,
+				EnrichmentError:  apiErr.Error(),
+			})
+			if err != nil {
+				return err
+			}
+			return r.DeleteEnrichmentJob(ctx, job.ID)
+		})
+	}
+
+	runAt := time.Now().Add(w.backoff(job.Attempts))
+	l.Debug("Person enrichment rescheduled", zap.Int32("id", person.ID), zap.Int32("attempts", job.Attempts), zap.Time("run_at", runAt), zap.Error(apiErr))
+	return w.repo.ExecTx(ctx, func(r repo.EnrichmentRepo) error {
+		err := r.SetPersonEnrichmentStatus(ctx, repo.SetPersonEnrichmentStatusParams{
+			ID:               person.ID,
+			EnrichmentStatus: repo.EnrichmentStatusPending,
+			EnrichmentError:  apiErr.Error(),
+		})
+		if err != nil {
+			return err
+		}
+		return r.RescheduleEnrichmentJob(ctx, repo.RescheduleEnrichmentJobParams{
+			ID:        job.ID,
+			RunAt:     runAt,
+			LastError: apiErr.Error(),
+		})
+	})
+}
+
+// backoff is the wait after the given failed attempt: Backoff doubled for every
+// earlier attempt, capped at MaxBackoff.
+func (w *EnrichmentWorker) backoff(attempts int32) time.Duration {
+	d := w.cfg.Backoff
+	for i := int32(1); i < attempts && (w.cfg.MaxBackoff == 0 || d < w.cfg.MaxBackoff); i++ {
+		d *= 2
+	}
+	if w.cfg.MaxBackoff > 0 && d > w.cfg.MaxBackoff {
+		d = w.cfg.MaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/exporter"
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+type ExportService interface {
+	ExportTasks(ctx context.Context, w exporter.Writer, columns []string, user_id int, from_dt, to_dt time.Time, clip bool) error
+	ExportPersonReport(ctx context.Context, w exporter.Writer, columns []string, user_id int, period string, from_dt, to_dt time.Time, clip bool) error
+	ExportTeamReport(ctx context.Context, w exporter.Writer, columns []string, filter Filter, sortBy string, desc bool, from_dt, to_dt time.Time, clip bool) error
+}
+
+type exportSvc struct {
+	repo    repo.ExportRepo
+	reports ReportsService
+}
+
+func NewExportService(repo repo.ExportRepo, reports ReportsService) ExportService {
+	return &exportSvc{
+		repo:    repo,
+		reports: reports,
+	}
+}
+
+func fullName(surname, name, patronymic string) string {
+	return strings.TrimSpace(strings.Join([]string{surname, name, patronymic}, " "))
+}
+
+var taskExportColumns = []exporter.Column[repo.ExportTaskRow]{
+	{Key: "id", Header: "Task ID", Value: func(r repo.ExportTaskRow) any { return r.ID }},
+	{Key: "user_id", Header: "Person ID", Value: func(r repo.ExportTaskRow) any { return r.UserID }},
+	{Key: "person", Header: "Person", Value: func(r repo.ExportTaskRow) any { return fullName(r.Surname, r.Name, r.Patronymic.String) }},
+	{Key: "surname", Header: "Surname", Value: func(r repo.ExportTaskRow) any { return r.Surname }},
+	{Key: "name", Header: "Name", Value: func(r repo.ExportTaskRow) any { return r.Name }},
+	{Key: "patronymic", Header: "Patronymic", Value: func(r repo.ExportTaskRow) any { return r.Patronymic.String }},
+	{Key: "description", Header: "Description", Value: func(r repo.ExportTaskRow) any { return r.Description }},
+	{Key: "status", Header: "Status", Value: func(r repo.ExportTaskRow) any { return string(r.Status) }},
+	{Key: "start_dt", Header: "Start", Value: func(r repo.ExportTaskRow) any { return r.StartDt.Time }},
+	{Key: "end_dt", Header: "End", Value: func(r repo.ExportTaskRow) any { return r.EndDt.Time }},
+	{Key: "total_seconds", Header: "Total seconds", Value: func(r repo.ExportTaskRow) any { return r.TotalSeconds }},
+	{Key: "duration", Header: "Duration", Value: func(r repo.ExportTaskRow) any { return isoDuration(r.TotalSeconds) }},
+	{Key: "hours", Header: "Hours", Value: func(r repo.ExportTaskRow) any { return float64(r.TotalSeconds) / 3600 }},
+}
+
+// ExportTasks streams the finished tasks of a person (or of everyone when user_id is 0)
+// within [from_dt, to_dt], like GetOrderedTasks does for a single person, including its clip mode.
+func (s *exportSvc) ExportTasks(ctx context.Context, w exporter.Writer, columns []string, user_id int, from_dt, to_dt time.Time, clip bool) error {
+	if !to_dt.After(from_dt) {
+		return ErrInvalidRange
+	}
+
+	table, err := exporter.NewTable(w, taskExportColumns, columns)
+	if err != nil {
+		return err
+	}
+	if err := table.WriteHeader(); err != nil {
+		return err
+	}
+
+	return s.repo.StreamOrderedTasks(ctx, repo.StreamOrderedTasksParams{
+		UserID:  int32(user_id),
+		StartDt: from_dt,
+		EndDt:   to_dt,
+		Clip:    clip,
+		Now:     time.Now(),
+	}, table.WriteRow)
+}
+
+// reportExportRow is one task of one bucket; buckets without tasks get a single row with Task unset.
+type reportExportRow struct {
+	Bucket ReportBucket
+	Task   *ReportTask
+}
+
+var reportExportColumns = []exporter.Column[reportExportRow]{
+	{Key: "bucket_start", Header: "Period start", Value: func(r reportExportRow) any { return r.Bucket.Start }},
+	{Key: "bucket_end", Header: "Period end", Value: func(r reportExportRow) any { return r.Bucket.End }},
+	{Key: "bucket_total_seconds", Header: "Period total seconds", Value: func(r reportExportRow) any { return r.Bucket.TotalSeconds }},
+	{Key: "bucket_duration", Header: "Period duration", Value: func(r reportExportRow) any { return r.Bucket.Duration }},
+	{Key: "bucket_amount", Header: "Period amount", Value: func(r reportExportRow) any { return r.Bucket.Amount }},
+	{Key: "task_id", Header: "Task ID", Value: func(r reportExportRow) any {
+		if r.Task == nil {
+			return nil
+		}
+		return r.Task.TaskID
+	}},
+	{Key: "description", Header: "Description", Value: func(r reportExportRow) any {
+		if r.Task == nil {
+			return nil
+		}
+		return r.Task.Description
+	}},
+	{Key: "total_seconds", Header: "Task total seconds", Value: func(r reportExportRow) any {
+		if r.Task == nil {
+			return int64(0)
+		}
+		return r.Task.TotalSeconds
+	}},
+	{Key: "duration", Header: "Task duration", Value: func(r reportExportRow) any {
+		if r.Task == nil {
+			return nil
+		}
+		return r.Task.Duration
+	}},
+	{Key: "amount", Header: "Task amount", Value: func(r reportExportRow) any {
+		if r.Task == nil {
+			return nil
+		}
+		return r.Task.Amount
+	}},
+}
+
+func (s *exportSvc) ExportPersonReport(ctx context.Context, w exporter.Writer, columns []string, user_id int, period string, from_dt, to_dt time.Time, clip bool) error {
+	table, err := exporter.NewTable(w, reportExportColumns, columns)
+	if err != nil {
+		return err
+	}
+
+	report, err := s.reports.GetPersonReport(ctx, user_id, period, from_dt, to_dt, clip)
+	if err != nil {
+		return err
+	}
+
+	if err := table.WriteHeader(); err != nil {
+		return err
+	}
+	for _, bucket := range report.Buckets {
+		if len(bucket.Tasks) == 0 {
+			if err := table.WriteRow(reportExportRow{Bucket: bucket}); err != nil {
+				return err
+			}
+			continue
+		}
+		for i := range bucket.Tasks {
+			if err := table.WriteRow(reportExportRow{Bucket: bucket, Task: &bucket.Tasks[i]}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var teamExportColumns = []exporter.Column[PersonWorkload]{
+	{Key: "id", Header: "Person ID", Value: func(p PersonWorkload) any { return p.ID }},
+	{Key: "person", Header: "Person", Value: func(p PersonWorkload) any { return fullName(p.Surname, p.Name, p.Patronymic) }},
+	{Key: "surname", Header: "Surname", Value: func(p PersonWorkload) any { return p.Surname }},
+	{Key: "name", Header: "Name", Value: func(p PersonWorkload) any { return p.Name }},
+	{Key: "patronymic", Header: "Patronymic", Value: func(p PersonWorkload) any { return p.Patronymic }},
+	{Key: "address", Header: "Address", Value: func(p PersonWorkload) any { return p.Address }},
+	{Key: "task_count", Header: "Tasks", Value: func(p PersonWorkload) any { return p.TaskCount }},
+	{Key: "total_seconds", Header: "Total seconds", Value: func(p PersonWorkload) any { return p.TotalSeconds }},
+	{Key: "duration", Header: "Duration", Value: func(p PersonWorkload) any { return p.Duration }},
+	{Key: "longest_task_id", Header: "Longest task ID", Value: func(p PersonWorkload) any { return p.LongestTaskID }},
+	{Key: "longest_task_seconds", Header: "Longest task seconds", Value: func(p PersonWorkload) any { return p.LongestTaskSeconds }},
+	{Key: "average_task_seconds", Header: "Average task seconds", Value: func(p PersonWorkload) any { return p.AverageTaskSeconds }},
+	{Key: "amount", Header: "Amount", Value: func(p PersonWorkload) any { return p.Amount }},
+}
+
+func (s *exportSvc) ExportTeamReport(ctx context.Context, w exporter.Writer, columns []string, filter Filter, sortBy string, desc bool, from_dt, to_dt time.Time, clip bool) error {
+	table, err := exporter.NewTable(w, teamExportColumns, columns)
+	if err != nil {
+		return err
+	}
+
+	workload, err := s.reports.GetTeamReport(ctx, filter, sortBy, desc, from_dt, to_dt, clip)
+	if err != nil {
+		return err
+	}
+
+	if err := table.WriteHeader(); err != nil {
+		return err
+	}
+	for _, p := range workload {
+		if err := table.WriteRow(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
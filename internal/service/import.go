@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/importer"
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+// emptyDescription replaces missing descriptions, other trackers allow entries without one.
+const emptyDescription = "(no description)"
+
+type ImportService interface {
+	Import(ctx context.Context, format string, r io.Reader, dryRun bool) (ImportReport, error)
+}
+
+type importSvc struct {
+	tasks  repo.TasksRepo
+	people repo.PeopleRepo
+}
+
+func NewImportService(tasks repo.TasksRepo, people repo.PeopleRepo) ImportService {
+	return &importSvc{
+		tasks:  tasks,
+		people: people,
+	}
+}
+
+// Import reads time entries from an export and creates one finished task per entry.
+// Entries are matched to people by passport when the source has it, by full name otherwise.
+// Entries that can't be imported, including those overlapping tracked time of the person or
+// an earlier entry of the import, are listed in the report and skipped; the valid ones are
+// created in a single transaction, unless dryRun is set, in which case nothing is written.
+func (s *importSvc) Import(ctx context.Context, format string, r io.Reader, dryRun bool) (ImportReport, error) {
+	entries, err := importer.Parse(format, r)
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	people, err := s.people.ListPeople(ctx, repo.ListPeopleParams{})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return ImportReport{}, err
+	}
+	index := newPeopleIndex(people)
+
+	report := ImportReport{
+		DryRun: dryRun,
+		Total:  len(entries),
+		Failed: []ImportFailure{},
+	}
+	now := time.Now()
+	var candidates []importRow
+	for _, e := range entries {
+		userID, err := validateImportEntry(e, index, now)
+		if err != nil {
+			report.Failed = append(report.Failed, ImportFailure{Row: e.Row, Error: err.Error()})
+			continue
+		}
+		description := strings.TrimSpace(e.Description)
+		if description == "" {
+			description = emptyDescription
+		}
+		candidates = append(candidates, importRow{
+			row: e.Row,
+			arg: repo.CreateFinishedTaskParams{
+				UserID:      userID,
+				Description: description,
+				StartDt:     sql.NullTime{Time: e.Start, Valid: true},
+				EndDt:       sql.NullTime{Time: e.End, Valid: true},
+				CreatedAt:   now,
+			},
+		})
+	}
+
+	// the checks run in the write transaction, so they see what was tracked up to the insert
+	var valid []repo.CreateFinishedTaskParams
+	var rejected []ImportFailure
+	importRows := func(r repo.TasksRepo) error {
+		valid, rejected = nil, nil
+		for _, c := range candidates {
+			err := checkImportRow(ctx, r, c.arg, valid, now)
+			if errors.Is(err, ErrPeriodLocked) || errors.Is(err, ErrOverlap) {
+				rejected = append(rejected, ImportFailure{Row: c.row, Error: err.Error()})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			valid = append(valid, c.arg)
+			if dryRun {
+				continue
+			}
+
+			id, err := r.CreateFinishedTask(ctx, c.arg)
+			if err != nil {
+				return err
+			}
+			_, err = r.CreateClosedTimeEntry(ctx, repo.CreateClosedTimeEntryParams{
+				TaskID:  id,
+				StartDt: c.arg.StartDt.Time,
+				EndDt:   c.arg.EndDt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if dryRun || len(candidates) == 0 {
+		err = importRows(s.tasks)
+	} else {
+		err = s.tasks.ExecTx(ctx, importRows)
+	}
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	report.Failed = append(report.Failed, rejected...)
+	sort.SliceStable(report.Failed, func(i, j int) bool {
+		return report.Failed[i].Row < report.Failed[j].Row
+	})
+	if !dryRun {
+		report.Imported = len(valid)
+	}
+	return report, nil
+}
+
+func validateImportEntry(e importer.Entry, index *peopleIndex, now time.Time) (int32, error) {
+	if e.Err != nil {
+		return 0, e.Err
+	}
+	if err := validateInterval(e.Start, e.End, now); err != nil {
+		return 0, err
+	}
+	return index.find(e)
+}
+
+type importRow struct {
+	row int
+	arg repo.CreateFinishedTaskParams
+}
+
+// checkImportRow fails with ErrPeriodLocked or ErrOverlap when the entry can't be imported
+// next to the tracked time of the person and the entries accepted before it.
+func checkImportRow(ctx context.Context, r repo.TasksRepo, arg repo.CreateFinishedTaskParams, accepted []repo.CreateFinishedTaskParams, now time.Time) error {
+	start, end := arg.StartDt.Time, arg.EndDt.Time
+	if err := checkLocked(ctx, r, arg.UserID, start, end); err != nil {
+		return err
+	}
+	if err := checkOverlap(ctx, r, arg.UserID, 0, start, end, now); err != nil {
+		return err
+	}
+	if overlapsImported(accepted, arg.UserID, start, end) {
+		return ErrOverlap
+	}
+	return nil
+}
+
+// overlapsImported tells whether [start, end) intersects an entry of the person accepted
+// earlier in the same import, which checkOverlap can't see in a dry run as nothing is stored.
+func overlapsImported(valid []repo.CreateFinishedTaskParams, user_id int32, start, end time.Time) bool {
+	for _, arg := range valid {
+		if arg.UserID == user_id && arg.StartDt.Time.Before(end) && start.Before(arg.EndDt.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+// peopleIndex resolves import entries to people IDs.
+type peopleIndex struct {
+	byPassport map[[2]int32]int32
+	byName     map[string][]int32
+}
+
+func newPeopleIndex(people []repo.Person) *peopleIndex {
+	index := &peopleIndex{
+		byPassport: make(map[[2]int32]int32, len(people)),
+		byName:     make(map[string][]int32),
+	}
+	for _, p := range people {
+		index.byPassport[[2]int32{p.PassportSerie, p.PassportNumber}] = p.ID
+
+		// other trackers usually keep "Name Surname", we store surname first
+		keys := map[string]bool{
+			nameKey(p.Surname, p.Name): true,
+			nameKey(p.Name, p.Surname): true,
+		}
+		if p.Patronymic.Valid && p.Patronymic.String != "" {
+			keys[nameKey(p.Surname, p.Name, p.Patronymic.String)] = true
+			keys[nameKey(p.Name, p.Patronymic.String, p.Surname)] = true
+		}
+		for key := range keys {
+			index.byName[key] = append(index.byName[key], p.ID)
+		}
+	}
+	return index
+}
+
+func (i *peopleIndex) find(e importer.Entry) (int32, error) {
+	if e.PassportSerie != 0 || e.PassportNumber != 0 {
+		id, ok := i.byPassport[[2]int32{e.PassportSerie, e.PassportNumber}]
+		if !ok {
+			return 0, fmt.Errorf("no person with passport %d %d", e.PassportSerie, e.PassportNumber)
+		}
+		return id, nil
+	}
+
+	if strings.TrimSpace(e.FullName) == "" {
+		return 0, errors.New("entry has no person")
+	}
+	ids := i.byName[nameKey(strings.Fields(e.FullName)...)]
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("no person named %q", e.FullName)
+	case 1:
+		return ids[0], nil
+	}
+	return 0, fmt.Errorf("%d people named %q, use passport instead", len(ids), e.FullName)
+}
+
+func nameKey(parts ...string) string {
+	return strings.ToLower(strings.Join(parts, " "))
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/pdf"
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+type InvoicesService interface {
+	CreateInvoice(ctx context.Context, client_id int32, from_dt, to_dt time.Time, taxRate string) (int32, error)
+	GetInvoice(ctx context.Context, id int32) (Invoice, error)
+	ListInvoices(ctx context.Context, client_id int32) ([]Invoice, error)
+	WriteInvoicePDF(w io.Writer, invoice Invoice) error
+}
+
+type invoicesSvc struct {
+	repo repo.InvoicesRepo
+}
+
+func NewInvoicesService(repo repo.InvoicesRepo) InvoicesService {
+	return &invoicesSvc{
+		repo: repo,
+	}
+}
+
+// CreateInvoice bills the finished work sessions of the client's projects within [from_dt, to_dt),
+// one line per project, person and rate. Sessions are billed like in the reports; running sessions,
+// non-billable tasks and time without a rate are left out. The invoice is numbered from a sequence
+// and stored as a snapshot, so later changes to tasks, rates or names don't affect it.
+// taxRate is a percentage such as "20" or "7.5"; empty means no tax.
+func (s *invoicesSvc) CreateInvoice(ctx context.Context, client_id int32, from_dt, to_dt time.Time, taxRate string) (int32, error) {
+	if !to_dt.After(from_dt) {
+		return 0, ErrInvalidRange
+	}
+	if taxRate == "" {
+		taxRate = "0"
+	}
+	rate, err := parseCents(taxRate)
+	if err != nil || rate > 100_00 {
+		return 0, ErrInvalidTaxRate
+	}
+
+	var id int32
+	err = s.repo.ExecTx(ctx, func(r repo.InvoicesRepo) error {
+		// the lock makes concurrent invoices of the client wait for this one, so they
+		// see it when checking for overlaps and the same time isn't billed twice
+		client, err := r.GetClientByIDForUpdate(ctx, client_id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNoResult
+			}
+			return err
+		}
+
+		count, err := r.CountOverlappingInvoices(ctx, repo.CountOverlappingInvoicesParams{
+			ClientID: client.ID,
+			ToDt:     to_dt,
+			FromDt:   from_dt,
+		})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrAlreadyInvoiced
+		}
+
+		lines, err := r.GetBillableLines(ctx, repo.GetBillableLinesParams{
+			ToDt:     to_dt,
+			FromDt:   from_dt,
+			ClientID: client.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return ErrNothingToInvoice
+		}
+
+		var subtotal int64
+		for _, line := range lines {
+			subtotal += line.AmountCents
+		}
+		// rate is in hundredths of a percent; round half up to the cent
+		tax := (subtotal*rate + 5000) / 10000
+
+		id, err = r.CreateInvoice(ctx, repo.CreateInvoiceParams{
+			ClientID:      sql.NullInt32{Int32: client.ID, Valid: true},
+			ClientName:    client.Name,
+			ClientAddress: client.Address,
+			FromDt:        from_dt,
+			ToDt:          to_dt,
+			TaxRate:       formatCents(rate),
+			Subtotal:      formatCents(subtotal),
+			Tax:           formatCents(tax),
+			Total:         formatCents(subtotal + tax),
+			CreatedAt:     time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			err := r.CreateInvoiceLine(ctx, repo.CreateInvoiceLineParams{
+				InvoiceID:   id,
+				ProjectID:   line.ProjectID,
+				ProjectName: line.ProjectName,
+				UserID:      line.UserID,
+				PersonName:  line.PersonName,
+				Rate:        formatCents(line.RateCents),
+				Seconds:     line.TotalSeconds,
+				Amount:      formatCents(line.AmountCents),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return id, err
+}
+
+// GetInvoice returns an invoice with its lines.
+func (s *invoicesSvc) GetInvoice(ctx context.Context, id int32) (Invoice, error) {
+	invoice, err := s.repo.GetInvoiceByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Invoice{}, ErrNoResult
+		}
+		return Invoice{}, err
+	}
+
+	lines, err := s.repo.ListInvoiceLines(ctx, id)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	result := toInvoice(invoice)
+	result.Lines = []InvoiceLine{}
+	for _, line := range lines {
+		result.Lines = append(result.Lines, InvoiceLine{
+			ProjectID:   line.ProjectID,
+			ProjectName: line.ProjectName,
+			UserID:      line.UserID,
+			PersonName:  line.PersonName,
+			Rate:        line.Rate,
+			Seconds:     line.Seconds,
+			// hours with two decimals, rounded half up
+			Hours:  formatCents((line.Seconds*100 + 1800) / 3600),
+			Amount: line.Amount,
+		})
+	}
+	return result, nil
+}
+
+// ListInvoices returns the invoices of a client, or of every client when client_id is 0,
+// newest first and without their lines.
+func (s *invoicesSvc) ListInvoices(ctx context.Context, client_id int32) ([]Invoice, error) {
+	invoices, err := s.repo.ListInvoices(ctx, client_id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Invoice{}
+	for _, invoice := range invoices {
+		result = append(result, toInvoice(invoice))
+	}
+	return result, nil
+}
+
+// WriteInvoicePDF renders an invoice returned by GetInvoice as an A4 PDF document.
+func (s *invoicesSvc) WriteInvoicePDF(w io.Writer, invoice Invoice) error {
+	_, err := renderInvoice(invoice).WriteTo(w)
+	return err
+}
+
+func toInvoice(invoice repo.Invoice) Invoice {
+	return Invoice{
+		ID:            invoice.ID,
+		Number:        invoice.Number,
+		ClientID:      invoice.ClientID.Int32,
+		ClientName:    invoice.ClientName,
+		ClientAddress: invoice.ClientAddress,
+		FromDt:        invoice.FromDt,
+		ToDt:          invoice.ToDt,
+		TaxRate:       invoice.TaxRate,
+		Subtotal:      invoice.Subtotal,
+		Tax:           invoice.Tax,
+		Total:         invoice.Total,
+		CreatedAt:     invoice.CreatedAt,
+	}
+}
+
+// Layout of the invoice document, in points.
+const (
+	invoiceMargin     = 50
+	invoiceFontSize   = 10
+	invoiceLineHeight = 16
+	// right edges of the number columns
+	invoiceHoursX  = 390
+	invoiceRateX   = 470
+	invoiceAmountX = pdf.PageWidth - invoiceMargin
+	invoicePersonX = 220
+)
+
+func renderInvoice(invoice Invoice) *pdf.Document {
+	doc := pdf.New()
+	left, right := float64(invoiceMargin), invoiceAmountX
+	y := pdf.PageHeight - 70
+
+	doc.Text(left, y, 20, true, "Invoice "+invoice.Number)
+	y -= 30
+	doc.Text(left, y, invoiceFontSize, false, "Date: "+invoice.CreatedAt.Format("2006-01-02"))
+	y -= invoiceLineHeight
+	doc.Text(left, y, invoiceFontSize, false, fmt.Sprintf("Period: %s - %s", invoice.FromDt.Format(auditLayout), invoice.ToDt.Format(auditLayout)))
+	y -= 2 * invoiceLineHeight
+	doc.Text(left, y, invoiceFontSize, true, "Bill to")
+	y -= invoiceLineHeight
+	doc.Text(left, y, invoiceFontSize, false, invoice.ClientName)
+	if invoice.ClientAddress != "" {
+		y -= invoiceLineHeight
+		doc.Text(left, y, invoiceFontSize, false, invoice.ClientAddress)
+	}
+	y -= 2 * invoiceLineHeight
+
+	header := func() {
+		doc.Text(left, y, invoiceFontSize, true, "Project")
+		doc.Text(invoicePersonX, y, invoiceFontSize, true, "Person")
+		doc.TextRight(invoiceHoursX, y, invoiceFontSize, true, "Hours")
+		doc.TextRight(invoiceRateX, y, invoiceFontSize, true, "Rate")
+		doc.TextRight(right, y, invoiceFontSize, true, "Amount")
+		doc.Line(left, y-5, right, y-5)
+		y -= invoiceLineHeight + 4
+	}
+	header()
+	for _, line := range invoice.Lines {
+		if y < invoiceMargin+invoiceLineHeight {
+			doc.AddPage()
+			y = pdf.PageHeight - invoiceMargin
+			header()
+		}
+		doc.Text(left, y, invoiceFontSize, false, pdf.Fit(line.ProjectName, invoiceFontSize, false, invoicePersonX-left-10))
+		doc.Text(invoicePersonX, y, invoiceFontSize, false, pdf.Fit(line.PersonName, invoiceFontSize, false, invoiceHoursX-invoicePersonX-50))
+		doc.TextRight(invoiceHoursX, y, invoiceFontSize, false, line.Hours)
+		doc.TextRight(invoiceRateX, y, invoiceFontSize, false, line.Rate)
+		doc.TextRight(right, y, invoiceFontSize, false, line.Amount)
+		y -= invoiceLineHeight
+	}
+
+	if y < invoiceMargin+4*invoiceLineHeight {
+		doc.AddPage()
+		y = pdf.PageHeight - invoiceMargin
+	}
+	doc.Line(left, y+invoiceLineHeight-5, right, y+invoiceLineHeight-5)
+	y -= 4
+	totals := []struct {
+		label string
+		value string
+		bold  bool
+	}{
+		{"Subtotal", invoice.Subtotal, false},
+		{fmt.Sprintf("Tax (%s%%)", invoice.TaxRate), invoice.Tax, false},
+		{"Total", invoice.Total, true},
+	}
+	for _, t := range totals {
+		doc.TextRight(invoiceRateX, y, invoiceFontSize, t.bold, t.label)
+		doc.TextRight(right, y, invoiceFontSize, t.bold, t.value)
+		y -= invoiceLineHeight
+	}
+	return doc
+}
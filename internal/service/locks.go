@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+// LocksService closes accounting periods. TasksService refuses to write task time into
+// a locked period with ErrPeriodLocked.
+type LocksService interface {
+	CreateLock(ctx context.Context, lock PeriodLock) (int32, error)
+	ListLocks(ctx context.Context, user_id int32) ([]PeriodLock, error)
+	DeleteLock(ctx context.Context, id int32) error
+}
+
+type locksSvc struct {
+	repo repo.LocksRepo
+}
+
+func NewLocksService(repo repo.LocksRepo) LocksService {
+	return &locksSvc{
+		repo: repo,
+	}
+}
+
+// CreateLock locks [FromDt, ToDt) for a person, or for everyone when UserID is 0.
+func (s *locksSvc) CreateLock(ctx context.Context, lock PeriodLock) (int32, error) {
+	if !lock.ToDt.After(lock.FromDt) {
+		return 0, ErrInvalidRange
+	}
+
+	arg := repo.CreatePeriodLockParams{
+		FromDt:    lock.FromDt,
+		ToDt:      lock.ToDt,
+		LockedBy:  lock.LockedBy,
+		Reason:    lock.Reason,
+		CreatedAt: time.Now(),
+	}
+	if lock.UserID != 0 {
+		_, err := s.repo.GetPersonByID(ctx, lock.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, ErrNoResult
+			}
+			return 0, err
+		}
+		arg.UserID = sql.NullInt32{Int32: lock.UserID, Valid: true}
+	}
+
+	return s.repo.CreatePeriodLock(ctx, arg)
+}
+
+// ListLocks returns the locks, latest period first. With a non-zero user_id only the locks
+// applying to that person are returned, including the global ones.
+func (s *locksSvc) ListLocks(ctx context.Context, user_id int32) ([]PeriodLock, error) {
+	locks, err := s.repo.ListPeriodLocks(ctx, user_id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []PeriodLock{}
+	for _, lock := range locks {
+		result = append(result, PeriodLock{
+			ID:        lock.ID,
+			UserID:    lock.UserID.Int32,
+			FromDt:    lock.FromDt,
+			ToDt:      lock.ToDt,
+			LockedBy:  lock.LockedBy,
+			Reason:    lock.Reason,
+			CreatedAt: lock.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// DeleteLock reopens a locked period.
+func (s *locksSvc) DeleteLock(ctx context.Context, id int32) error {
+	n, err := s.repo.DeletePeriodLock(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNoResult
+	}
+	return nil
+}
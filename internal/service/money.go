@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Money is handled as integer cents so that amounts add up exactly; the database keeps
+// rates as numeric(12, 2) and computes amounts with numeric arithmetic as well.
+
+var amountPattern = regexp.MustCompile(`^\d{1,10}(\.\d{1,2})?$`)
+
+// parseCents parses a non-negative decimal amount with at most two fraction digits, e.g. "45.5".
+func parseCents(amount string) (int64, error) {
+	if !amountPattern.MatchString(amount) {
+		return 0, ErrInvalidAmount
+	}
+
+	units, fraction, _ := strings.Cut(amount, ".")
+	fraction += strings.Repeat("0", 2-len(fraction))
+	u, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	f, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return u*100 + f, nil
+}
+
+// formatCents formats cents as a decimal amount with two fraction digits, e.g. 4550 as "45.50".
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
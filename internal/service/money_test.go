@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCents(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "0", want: 0},
+		{input: "45", want: 4500},
+		{input: "45.5", want: 4550},
+		{input: "45.05", want: 4505},
+		{input: "9999999999.99", want: 999999999999},
+		{input: "45.555", wantErr: true},
+		{input: "-1", wantErr: true},
+		{input: ".5", wantErr: true},
+		{input: "45.", wantErr: true},
+		{input: "1e3", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseCents(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("parseCents() error = %v, want %v", err, ErrInvalidAmount)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("parseCents() = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	tests := []struct {
+		cents int64
+		want  string
+	}{
+		{cents: 0, want: "0.00"},
+		{cents: 5, want: "0.05"},
+		{cents: 4550, want: "45.50"},
+		{cents: 123456, want: "1234.56"},
+		{cents: -4505, want: "-45.05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatCents(tt.cents); got != tt.want {
+				t.Errorf("formatCents(%d) = %q, want %q", tt.cents, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+// Work days are stored as a bit mask, Monday being bit 0 and Sunday bit 6.
+var weekdayNames = [7]string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// Night hours used by the overtime report unless the request sets its own.
+const (
+	defaultNightStart = 22 * 60
+	defaultNightEnd   = 6 * 60
+)
+
+func weekdayBit(d time.Weekday) int32 {
+	return 1 << ((int(d) + 6) % 7)
+}
+
+func parseWorkDays(days []string) (int32, error) {
+	var mask int32
+	for _, day := range days {
+		found := false
+		for i, name := range weekdayNames {
+			if day == name {
+				mask |= 1 << i
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, ErrInvalidWorkDay
+		}
+	}
+	return mask, nil
+}
+
+func formatWorkDays(mask int32) []string {
+	days := []string{}
+	for i, name := range weekdayNames {
+		if mask&(1<<i) != 0 {
+			days = append(days, name)
+		}
+	}
+	return days
+}
+
+// parseClock parses a time of day formatted as HH:MM into minutes since midnight.
+// 24:00 is accepted so that a working day can end at midnight.
+func parseClock(s string) (int32, error) {
+	var hours, minutes int32
+	if len(s) != 5 {
+		return 0, ErrInvalidClock
+	}
+	if _, err := fmt.Sscanf(s, "%02d:%02d", &hours, &minutes); err != nil {
+		return 0, ErrInvalidClock
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || hours*60+minutes > 24*60 {
+		return 0, ErrInvalidClock
+	}
+	return hours*60 + minutes, nil
+}
+
+func formatClock(minutes int32) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func toSchedule(person repo.Person) Schedule {
+	return Schedule{
+		UserID:        person.ID,
+		WeeklySeconds: person.WeeklySeconds,
+		Weekly:        isoDuration(person.WeeklySeconds),
+		WorkDays:      formatWorkDays(person.WorkDays),
+		WorkStart:     formatClock(person.WorkStartMinute),
+		WorkEnd:       formatClock(person.WorkEndMinute),
+	}
+}
+
+// overtimeRules are OvertimeRules with the times of day parsed into minutes.
+type overtimeRules struct {
+	nightStart   int32
+	nightEnd     int32
+	basis        string
+	outsideHours bool
+}
+
+// parseOvertimeRules fills in the defaults for unset rules, writing them back so the
+// report shows the rules it was computed with.
+func parseOvertimeRules(rules *OvertimeRules) (overtimeRules, error) {
+	if rules.NightStart == "" {
+		rules.NightStart = formatClock(defaultNightStart)
+	}
+	if rules.NightEnd == "" {
+		rules.NightEnd = formatClock(defaultNightEnd)
+	}
+	if rules.Basis == "" {
+		rules.Basis = PeriodDay
+	}
+	if rules.Basis != PeriodDay && rules.Basis != PeriodWeek {
+		return overtimeRules{}, ErrInvalidBasis
+	}
+
+	nightStart, err := parseClock(rules.NightStart)
+	if err != nil {
+		return overtimeRules{}, err
+	}
+	nightEnd, err := parseClock(rules.NightEnd)
+	if err != nil {
+		return overtimeRules{}, err
+	}
+	return overtimeRules{
+		nightStart:   nightStart,
+		nightEnd:     nightEnd,
+		basis:        rules.Basis,
+		outsideHours: rules.OutsideHours,
+	}, nil
+}
+
+func (r overtimeRules) isNight(minute int32) bool {
+	switch {
+	case r.nightStart < r.nightEnd:
+		return minute >= r.nightStart && minute < r.nightEnd
+	case r.nightStart > r.nightEnd:
+		return minute >= r.nightStart || minute < r.nightEnd
+	}
+	return false
+}
+
+type dayPart int
+
+const (
+	partInside dayPart = iota
+	partOutside
+	partNight
+)
+
+type daySegment struct {
+	from, to int32
+	part     dayPart
+}
+
+// daySegments cuts a work day into the night hours, the working hours and the rest,
+// in minutes since midnight. Night hours take precedence over working hours.
+func daySegments(person repo.Person, rules overtimeRules) []daySegment {
+	cuts := []int32{0, 24 * 60}
+	for _, m := range []int32{rules.nightStart, rules.nightEnd, person.WorkStartMinute, person.WorkEndMinute} {
+		i := 0
+		for i < len(cuts) && cuts[i] < m {
+			i++
+		}
+		if i < len(cuts) && cuts[i] == m {
+			continue
+		}
+		cuts = append(cuts[:i], append([]int32{m}, cuts[i:]...)...)
+	}
+
+	segments := make([]daySegment, 0, len(cuts)-1)
+	for i := 0; i+1 < len(cuts); i++ {
+		part := partOutside
+		switch {
+		case rules.isNight(cuts[i]):
+			part = partNight
+		case cuts[i] >= person.WorkStartMinute && cuts[i] < person.WorkEndMinute:
+			part = partInside
+		}
+		segments = append(segments, daySegment{from: cuts[i], to: cuts[i+1], part: part})
+	}
+	return segments
+}
+
+// overlapSeconds returns how many seconds [start, end) and [from, to) have in common.
+func overlapSeconds(start, end, from, to time.Time) int64 {
+	if from.After(start) {
+		start = from
+	}
+	if to.Before(end) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return int64(end.Sub(start) / time.Second)
+}
+
+// dailyOvertime classifies the sessions, already clipped to [from_dt, to_dt), day by day.
+// Time on days off is weekend time and time in the night hours of a work day is night time.
+// The rest is regular time up to the contracted hours of the day, or of the week on a weekly
+// basis, and overtime beyond that. Days before from_dt do not count towards a weekly allowance.
+func dailyOvertime(person repo.Person, rules overtimeRules, sessions []repo.GetPersonSessionsRow, from_dt, to_dt time.Time) []OvertimeBucket {
+	var dailySeconds int64
+	if n := bits.OnesCount32(uint32(person.WorkDays)); n > 0 {
+		dailySeconds = person.WeeklySeconds / int64(n)
+	}
+	segments := daySegments(person, rules)
+
+	days := []OvertimeBucket{}
+	var week time.Time
+	var weekRegular int64
+	for day := truncatePeriod(from_dt, PeriodDay); day.Before(to_dt); day = nextPeriod(day, PeriodDay) {
+		if start := truncatePeriod(day, PeriodWeek); !start.Equal(week) {
+			week = start
+			weekRegular = 0
+		}
+		bucket := OvertimeBucket{
+			Start: day,
+			End:   nextPeriod(day, PeriodDay),
+		}
+		workDay := person.WorkDays&weekdayBit(day.Weekday()) != 0
+		if workDay {
+			bucket.ExpectedSeconds = dailySeconds
+		}
+
+		var inside, outside int64
+		for _, session := range sessions {
+			tracked := overlapSeconds(session.StartDt, session.EndDt, bucket.Start, bucket.End)
+			if tracked == 0 {
+				continue
+			}
+			bucket.TrackedSeconds += tracked
+			if !workDay {
+				bucket.WeekendSeconds += tracked
+				continue
+			}
+			for _, segment := range segments {
+				seconds := overlapSeconds(session.StartDt, session.EndDt,
+					day.Add(time.Duration(segment.from)*time.Minute),
+					day.Add(time.Duration(segment.to)*time.Minute))
+				switch segment.part {
+				case partNight:
+					bucket.NightSeconds += seconds
+				case partInside:
+					inside += seconds
+				default:
+					outside += seconds
+				}
+			}
+		}
+
+		if rules.outsideHours {
+			bucket.OvertimeSeconds += outside
+		} else {
+			inside += outside
+		}
+		allowance := dailySeconds
+		if rules.basis == PeriodWeek {
+			allowance = max(person.WeeklySeconds-weekRegular, 0)
+		}
+		bucket.RegularSeconds = min(inside, allowance)
+		bucket.OvertimeSeconds += inside - bucket.RegularSeconds
+		weekRegular += bucket.RegularSeconds
+		bucket.BalanceSeconds = bucket.TrackedSeconds - bucket.ExpectedSeconds
+		days = append(days, bucket)
+	}
+	return days
+}
+
+func (t *OvertimeTotals) add(other OvertimeTotals) {
+	t.ExpectedSeconds += other.ExpectedSeconds
+	t.TrackedSeconds += other.TrackedSeconds
+	t.RegularSeconds += other.RegularSeconds
+	t.OvertimeSeconds += other.OvertimeSeconds
+	t.WeekendSeconds += other.WeekendSeconds
+	t.NightSeconds += other.NightSeconds
+	t.BalanceSeconds += other.BalanceSeconds
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+// 2024-03-04 is a Monday.
+func at(day, hour int) time.Time {
+	return time.Date(2024, 3, day, hour, 0, 0, 0, time.Local)
+}
+
+func session(start, end time.Time) repo.GetPersonSessionsRow {
+	return repo.GetPersonSessionsRow{StartDt: start, EndDt: end}
+}
+
+func TestDailyOvertime(t *testing.T) {
+	// Monday to Friday, 9:00 to 18:00.
+	fullTime := repo.Person{WeeklySeconds: 40 * 3600, WorkDays: 0b11111, WorkStartMinute: 9 * 60, WorkEndMinute: 18 * 60}
+	partTime := repo.Person{WeeklySeconds: 10 * 3600, WorkDays: 0b11111, WorkStartMinute: 9 * 60, WorkEndMinute: 18 * 60}
+	dayRules := overtimeRules{nightStart: defaultNightStart, nightEnd: defaultNightEnd, basis: PeriodDay}
+	weekRules := overtimeRules{nightStart: defaultNightStart, nightEnd: defaultNightEnd, basis: PeriodWeek}
+	outsideRules := overtimeRules{nightStart: defaultNightStart, nightEnd: defaultNightEnd, basis: PeriodDay, outsideHours: true}
+
+	tests := []struct {
+		name     string
+		person   repo.Person
+		rules    overtimeRules
+		sessions []repo.GetPersonSessionsRow
+		from, to time.Time
+		want     []OvertimeTotals
+	}{
+		{
+			name:     "long work day",
+			person:   fullTime,
+			rules:    dayRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(4, 9), at(4, 19))},
+			from:     at(4, 0),
+			to:       at(5, 0),
+			want: []OvertimeTotals{
+				{ExpectedSeconds: 28800, TrackedSeconds: 36000, RegularSeconds: 28800, OvertimeSeconds: 7200, BalanceSeconds: 7200},
+			},
+		},
+		{
+			name:     "time outside working hours counted as regular",
+			person:   fullTime,
+			rules:    dayRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(4, 8), at(4, 12))},
+			from:     at(4, 0),
+			to:       at(5, 0),
+			want: []OvertimeTotals{
+				{ExpectedSeconds: 28800, TrackedSeconds: 14400, RegularSeconds: 14400, BalanceSeconds: -14400},
+			},
+		},
+		{
+			name:     "time outside working hours counted as overtime",
+			person:   fullTime,
+			rules:    outsideRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(4, 8), at(4, 12))},
+			from:     at(4, 0),
+			to:       at(5, 0),
+			want: []OvertimeTotals{
+				{ExpectedSeconds: 28800, TrackedSeconds: 14400, RegularSeconds: 10800, OvertimeSeconds: 3600, BalanceSeconds: -14400},
+			},
+		},
+		{
+			name:     "session through the night",
+			person:   fullTime,
+			rules:    dayRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(4, 21), at(5, 1))},
+			from:     at(4, 0),
+			to:       at(6, 0),
+			want: []OvertimeTotals{
+				{ExpectedSeconds: 28800, TrackedSeconds: 10800, RegularSeconds: 3600, NightSeconds: 7200, BalanceSeconds: -18000},
+				{ExpectedSeconds: 28800, TrackedSeconds: 3600, NightSeconds: 3600, BalanceSeconds: -25200},
+			},
+		},
+		{
+			name:     "day off",
+			person:   fullTime,
+			rules:    dayRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(9, 10), at(9, 12))},
+			from:     at(9, 0),
+			to:       at(10, 0),
+			want: []OvertimeTotals{
+				{TrackedSeconds: 7200, WeekendSeconds: 7200, BalanceSeconds: 7200},
+			},
+		},
+		{
+			name:     "daily allowance",
+			person:   partTime,
+			rules:    dayRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(4, 9), at(4, 15)), session(at(5, 9), at(5, 15))},
+			from:     at(4, 0),
+			to:       at(6, 0),
+			want: []OvertimeTotals{
+				{ExpectedSeconds: 7200, TrackedSeconds: 21600, RegularSeconds: 7200, OvertimeSeconds: 14400, BalanceSeconds: 14400},
+				{ExpectedSeconds: 7200, TrackedSeconds: 21600, RegularSeconds: 7200, OvertimeSeconds: 14400, BalanceSeconds: 14400},
+			},
+		},
+		{
+			name:     "weekly allowance",
+			person:   partTime,
+			rules:    weekRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(4, 9), at(4, 15)), session(at(5, 9), at(5, 15))},
+			from:     at(4, 0),
+			to:       at(6, 0),
+			want: []OvertimeTotals{
+				{ExpectedSeconds: 7200, TrackedSeconds: 21600, RegularSeconds: 21600, BalanceSeconds: 14400},
+				{ExpectedSeconds: 7200, TrackedSeconds: 21600, RegularSeconds: 14400, OvertimeSeconds: 7200, BalanceSeconds: 14400},
+			},
+		},
+		{
+			name:     "weekly allowance ignores days before the range",
+			person:   partTime,
+			rules:    weekRules,
+			sessions: []repo.GetPersonSessionsRow{session(at(4, 9), at(4, 15)), session(at(5, 9), at(5, 15))},
+			from:     at(5, 0),
+			to:       at(6, 0),
+			want: []OvertimeTotals{
+				{ExpectedSeconds: 7200, TrackedSeconds: 21600, RegularSeconds: 21600, BalanceSeconds: 14400},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := dailyOvertime(tt.person, tt.rules, tt.sessions, tt.from, tt.to)
+			if len(days) != len(tt.want) {
+				t.Fatalf("dailyOvertime() returned %d days, want %d", len(days), len(tt.want))
+			}
+			for i, day := range days {
+				if want := tt.from.AddDate(0, 0, i); !day.Start.Equal(want) {
+					t.Errorf("day %d starts at %v, want %v", i, day.Start, want)
+				}
+				if day.OvertimeTotals != tt.want[i] {
+					t.Errorf("day %d = %+v, want %+v", i, day.OvertimeTotals, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int32
+		wantErr bool
+	}{
+		{input: "00:00", want: 0},
+		{input: "09:30", want: 570},
+		{input: "24:00", want: 1440},
+		{input: "24:01", wantErr: true},
+		{input: "12:60", wantErr: true},
+		{input: "9:30", wantErr: true},
+		{input: "ab:cd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseClock(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidClock) {
+					t.Fatalf("parseClock() error = %v, want %v", err, ErrInvalidClock)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("parseClock() = %d, %v, want %d", got, err, tt.want)
+			}
+			if s := formatClock(got); s != tt.input {
+				t.Errorf("formatClock(%d) = %q, want %q", got, s, tt.input)
+			}
+		})
+	}
+}
+
+func TestParseWorkDays(t *testing.T) {
+	tests := []struct {
+		name    string
+		days    []string
+		want    int32
+		wantErr bool
+	}{
+		{name: "week", days: []string{"mon", "tue", "wed", "thu", "fri"}, want: 0b0011111},
+		{name: "weekend", days: []string{"sun", "sat"}, want: 0b1100000},
+		{name: "none", days: nil, want: 0},
+		{name: "unknown day", days: []string{"mon", "Tue"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWorkDays(tt.days)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWorkDay) {
+					t.Fatalf("parseWorkDays() error = %v, want %v", err, ErrInvalidWorkDay)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("parseWorkDays() = %07b, %v, want %07b", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeekdayBit(t *testing.T) {
+	if got := weekdayBit(time.Monday); got != 1 {
+		t.Errorf("weekdayBit(Monday) = %b, want 1", got)
+	}
+	if got := weekdayBit(time.Sunday); got != 1<<6 {
+		t.Errorf("weekdayBit(Sunday) = %b, want %b", got, 1<<6)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/gogoalish/timetracker/internal/repo"
 )
@@ -13,6 +14,9 @@ type PeopleService interface {
 	ListPeople(ctx context.Context, filter Filter) ([]Person, error)
 	DeletePerson(ctx context.Context, id int32) error
 	UpdatePerson(ctx context.Context, person UpdatedPerson) error
+	GetSchedule(ctx context.Context, id int32) (Schedule, error)
+	UpdateSchedule(ctx context.Context, schedule Schedule) error
+	RetryEnrichment(ctx context.Context, id int32) error
 }
 
 type ApiClient interface {
@@ -21,16 +25,16 @@ type ApiClient interface {
 
 type peopleSvc struct {
 	repo repo.PeopleRepo
-	api  ApiClient
 }
 
-func NewPeopleService(repo repo.PeopleRepo, api ApiClient) PeopleService {
+func NewPeopleService(repo repo.PeopleRepo) PeopleService {
 	return &peopleSvc{
 		repo: repo,
-		api:  api,
 	}
 }
 
+// CreatePerson stores the person with only the passport and queues the lookup of the
+// rest in the people info API, see EnrichmentWorker, so the API being down loses nobody.
 func (s *peopleSvc) CreatePerson(ctx context.Context, passportSerie, passportNumber int) (int32, error) {
 
 	// check if person already exists
@@ -45,26 +49,21 @@ func (s *peopleSvc) CreatePerson(ctx context.Context, passportSerie, passportNum
 		return 0, err
 	}
 
-	person, err := s.api.InfoGet(ctx, int32(passportSerie), int32(passportNumber))
-	if err != nil {
-		return 0, err
-	}
-
-	patronymic := sql.NullString{
-		String: person.Patronymic,
-		Valid:  true,
-	}
-	if patronymic.String == "" {
-		patronymic.Valid = false
-	}
-	return s.repo.CreatePerson(ctx, repo.CreatePersonParams{
-		Name:           person.Name,
-		Surname:        person.Surname,
-		Patronymic:     patronymic,
-		Address:        person.Address,
-		PassportNumber: int32(passportNumber),
-		PassportSerie:  int32(passportSerie),
+	var id int32
+	err = s.repo.ExecTx(ctx, func(r repo.PeopleRepo) error {
+		id, err = r.CreatePendingPerson(ctx, repo.CreatePendingPersonParams{
+			PassportNumber: int32(passportNumber),
+			PassportSerie:  int32(passportSerie),
+		})
+		if err != nil {
+			return err
+		}
+		return r.UpsertEnrichmentJob(ctx, repo.UpsertEnrichmentJobParams{
+			PersonID: id,
+			RunAt:    time.Now(),
+		})
 	})
+	return id, err
 }
 
 func (s *peopleSvc) ListPeople(ctx context.Context, filter Filter) (result []Person, err error) {
@@ -76,15 +75,19 @@ func (s *peopleSvc) ListPeople(ctx context.Context, filter Filter) (result []Per
 		n := int32(0)
 		filter.PassportSerie = &n
 	}
+	if filter.EnrichmentStatus != "" && !validEnrichmentStatus(repo.EnrichmentStatus(filter.EnrichmentStatus)) {
+		return nil, ErrInvalidEnrichmentStatus
+	}
 	var people []repo.Person
 	if filter.Limit == nil {
 		people, err = s.repo.ListPeople(ctx, repo.ListPeopleParams{
-			PassportSerie:  *filter.PassportSerie,
-			PassportNumber: *filter.PassportNumber,
-			Surname:        filter.Surname,
-			Name:           filter.Name,
-			Patronymic:     filter.Patronymic,
-			Address:        filter.Address,
+			PassportSerie:    *filter.PassportSerie,
+			PassportNumber:   *filter.PassportNumber,
+			Surname:          filter.Surname,
+			Name:             filter.Name,
+			Patronymic:       filter.Patronymic,
+			Address:          filter.Address,
+			EnrichmentStatus: filter.EnrichmentStatus,
 		})
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
@@ -95,14 +98,15 @@ func (s *peopleSvc) ListPeople(ctx context.Context, filter Filter) (result []Per
 			filter.Offset = &n
 		}
 		people, err = s.repo.ListPeopleWithLimit(ctx, repo.ListPeopleWithLimitParams{
-			Limit:          *filter.Limit,
-			Offset:         *filter.Offset - 1,
-			PassportSerie:  *filter.PassportSerie,
-			PassportNumber: *filter.PassportNumber,
-			Surname:        filter.Surname,
-			Name:           filter.Name,
-			Patronymic:     filter.Patronymic,
-			Address:        filter.Address,
+			Limit:            *filter.Limit,
+			Offset:           *filter.Offset - 1,
+			PassportSerie:    *filter.PassportSerie,
+			PassportNumber:   *filter.PassportNumber,
+			Surname:          filter.Surname,
+			Name:             filter.Name,
+			Patronymic:       filter.Patronymic,
+			Address:          filter.Address,
+			EnrichmentStatus: filter.EnrichmentStatus,
 		})
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
@@ -111,12 +115,14 @@ func (s *peopleSvc) ListPeople(ctx context.Context, filter Filter) (result []Per
 
 	for _, person := range people {
 		p := Person{
-			ID:             person.ID,
-			PassportNumber: person.PassportNumber,
-			PassportSerie:  person.PassportSerie,
-			Name:           person.Name,
-			Surname:        person.Surname,
-			Address:        person.Address,
+			ID:               person.ID,
+			PassportNumber:   person.PassportNumber,
+			PassportSerie:    person.PassportSerie,
+			Name:             person.Name,
+			Surname:          person.Surname,
+			Address:          person.Address,
+			EnrichmentStatus: string(person.EnrichmentStatus),
+			EnrichmentError:  person.EnrichmentError,
 		}
 		if person.Patronymic.Valid {
 			p.Patronymic = person.Patronymic.String
@@ -167,3 +173,84 @@ func (s *peopleSvc) UpdatePerson(ctx context.Context, person UpdatedPerson) erro
 		PassportNumber: person.PassportNumber,
 	})
 }
+
+func (s *peopleSvc) GetSchedule(ctx context.Context, id int32) (Schedule, error) {
+	person, err := s.repo.GetPersonByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Schedule{}, ErrNoResult
+		}
+		return Schedule{}, err
+	}
+	return toSchedule(person), nil
+}
+
+// UpdateSchedule replaces the whole schedule of a person. WeeklySeconds, WorkDays,
+// WorkStart and WorkEnd are read from the schedule, the rest is ignored.
+func (s *peopleSvc) UpdateSchedule(ctx context.Context, schedule Schedule) error {
+	workDays, err := parseWorkDays(schedule.WorkDays)
+	if err != nil {
+		return err
+	}
+	workStart, err := parseClock(schedule.WorkStart)
+	if err != nil {
+		return err
+	}
+	workEnd, err := parseClock(schedule.WorkEnd)
+	if err != nil {
+		return err
+	}
+	if schedule.WeeklySeconds < 0 || workStart >= workEnd {
+		return ErrInvalidSchedule
+	}
+
+	_, err = s.repo.GetPersonByID(ctx, schedule.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoResult
+		}
+		return err
+	}
+
+	return s.repo.UpdatePersonSchedule(ctx, repo.UpdatePersonScheduleParams{
+		ID:              schedule.UserID,
+		WeeklySeconds:   schedule.WeeklySeconds,
+		WorkDays:        workDays,
+		WorkStartMinute: workStart,
+		WorkEndMinute:   workEnd,
+	})
+}
+
+// RetryEnrichment looks the person up in the people info API again, whatever became of
+// the previous lookup. The person stays pending until the worker is done.
+func (s *peopleSvc) RetryEnrichment(ctx context.Context, id int32) error {
+	_, err := s.repo.GetPersonByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoResult
+		}
+		return err
+	}
+
+	return s.repo.ExecTx(ctx, func(r repo.PeopleRepo) error {
+		err := r.SetPersonEnrichmentStatus(ctx, repo.SetPersonEnrichmentStatusParams{
+			ID:               id,
+			EnrichmentStatus: repo.EnrichmentStatusPending,
+		})
+		if err != nil {
+			return err
+		}
+		return r.UpsertEnrichmentJob(ctx, repo.UpsertEnrichmentJobParams{
+			PersonID: id,
+			RunAt:    time.Now(),
+		})
+	})
+}
+
+func validEnrichmentStatus(status repo.EnrichmentStatus) bool {
+	switch status {
+	case repo.EnrichmentStatusPending, repo.EnrichmentStatusEnriched, repo.EnrichmentStatusFailed:
+		return true
+	}
+	return false
+}
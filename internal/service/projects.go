@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+type ProjectsService interface {
+	CreateClient(ctx context.Context, name, address string) (int32, error)
+	GetClient(ctx context.Context, id int32) (Client, error)
+	ListClients(ctx context.Context, name string) ([]Client, error)
+	UpdateClient(ctx context.Context, client Client) error
+	DeleteClient(ctx context.Context, id int32) error
+	CreateProject(ctx context.Context, client_id int32, name string, budgetSeconds int64) (int32, error)
+	GetProject(ctx context.Context, id int32) (Project, error)
+	ListProjects(ctx context.Context, client_id int32, name string) ([]Project, error)
+	UpdateProject(ctx context.Context, project UpdatedProject) error
+	DeleteProject(ctx context.Context, id int32) error
+}
+
+type projectsSvc struct {
+	repo repo.ProjectsRepo
+}
+
+func NewProjectsService(repo repo.ProjectsRepo) ProjectsService {
+	return &projectsSvc{
+		repo: repo,
+	}
+}
+
+func (s *projectsSvc) CreateClient(ctx context.Context, name, address string) (int32, error) {
+	return s.repo.CreateClient(ctx, repo.CreateClientParams{
+		Name:      name,
+		Address:   address,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *projectsSvc) GetClient(ctx context.Context, id int32) (Client, error) {
+	client, err := s.repo.GetClientByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Client{}, ErrNoResult
+		}
+		return Client{}, err
+	}
+	return toClient(client), nil
+}
+
+func (s *projectsSvc) ListClients(ctx context.Context, name string) ([]Client, error) {
+	clients, err := s.repo.ListClients(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Client{}
+	for _, client := range clients {
+		result = append(result, toClient(client))
+	}
+	return result, nil
+}
+
+// UpdateClient changes the non-empty fields of the client.
+func (s *projectsSvc) UpdateClient(ctx context.Context, client Client) error {
+	if _, err := s.GetClient(ctx, client.ID); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateClient(ctx, repo.UpdateClientParams{
+		ID:      client.ID,
+		Name:    client.Name,
+		Address: client.Address,
+	})
+}
+
+// DeleteClient removes a client; its projects are kept without a client.
+func (s *projectsSvc) DeleteClient(ctx context.Context, id int32) error {
+	if _, err := s.GetClient(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteClient(ctx, id)
+}
+
+// CreateProject creates a project for a client, or an internal one when client_id is 0.
+// A zero budget leaves the project without one.
+func (s *projectsSvc) CreateProject(ctx context.Context, client_id int32, name string, budgetSeconds int64) (int32, error) {
+	budget, err := toBudget(budgetSeconds)
+	if err != nil {
+		return 0, err
+	}
+	clientID, err := s.clientID(ctx, client_id)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.repo.CreateProject(ctx, repo.CreateProjectParams{
+		ClientID:      clientID,
+		Name:          name,
+		CreatedAt:     time.Now(),
+		BudgetSeconds: budget,
+	})
+}
+
+func (s *projectsSvc) GetProject(ctx context.Context, id int32) (Project, error) {
+	project, err := s.repo.GetProjectByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Project{}, ErrNoResult
+		}
+		return Project{}, err
+	}
+	return toProject(project), nil
+}
+
+func (s *projectsSvc) ListProjects(ctx context.Context, client_id int32, name string) ([]Project, error) {
+	projects, err := s.repo.ListProjects(ctx, repo.ListProjectsParams{
+		ClientID: client_id,
+		Name:     name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Project{}
+	for _, project := range projects {
+		result = append(result, toProject(project))
+	}
+	return result, nil
+}
+
+func (s *projectsSvc) UpdateProject(ctx context.Context, project UpdatedProject) error {
+	current, err := s.repo.GetProjectByID(ctx, project.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoResult
+		}
+		return err
+	}
+
+	arg := repo.UpdateProjectParams{
+		ID:            current.ID,
+		ClientID:      current.ClientID,
+		Name:          current.Name,
+		BudgetSeconds: current.BudgetSeconds,
+	}
+	if project.ClientID != nil {
+		arg.ClientID, err = s.clientID(ctx, *project.ClientID)
+		if err != nil {
+			return err
+		}
+	}
+	if project.Name != nil && *project.Name != "" {
+		arg.Name = *project.Name
+	}
+	if project.BudgetSeconds != nil {
+		arg.BudgetSeconds, err = toBudget(*project.BudgetSeconds)
+		if err != nil {
+			return err
+		}
+	}
+	return s.repo.UpdateProject(ctx, arg)
+}
+
+// DeleteProject removes a project; its tasks are kept without a project.
+func (s *projectsSvc) DeleteProject(ctx context.Context, id int32) error {
+	if _, err := s.GetProject(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteProject(ctx, id)
+}
+
+// clientID checks that the client exists, mapping 0 to no client.
+func (s *projectsSvc) clientID(ctx context.Context, id int32) (sql.NullInt32, error) {
+	if id == 0 {
+		return sql.NullInt32{}, nil
+	}
+	if _, err := s.GetClient(ctx, id); err != nil {
+		return sql.NullInt32{}, err
+	}
+	return sql.NullInt32{Int32: id, Valid: true}, nil
+}
+
+// toBudget maps a zero budget to no budget.
+func toBudget(seconds int64) (sql.NullInt64, error) {
+	if seconds < 0 {
+		return sql.NullInt64{}, ErrInvalidBudget
+	}
+	return sql.NullInt64{Int64: seconds, Valid: seconds > 0}, nil
+}
+
+func toClient(client repo.Client) Client {
+	return Client{
+		ID:        client.ID,
+		Name:      client.Name,
+		Address:   client.Address,
+		CreatedAt: client.CreatedAt,
+	}
+}
+
+func toProject(project repo.Project) Project {
+	result := Project{
+		ID:        project.ID,
+		ClientID:  project.ClientID.Int32,
+		Name:      project.Name,
+		CreatedAt: project.CreatedAt,
+	}
+	if project.BudgetSeconds.Valid {
+		result.BudgetSeconds = project.BudgetSeconds.Int64
+		result.Budget = isoDuration(project.BudgetSeconds.Int64)
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+// RatesService keeps the history of hourly rates. The rate of a work session is the latest
+// one in effect when the session started, looked up on the task first, then on its project
+// and then on the person; sessions of non-billable tasks are not billed.
+type RatesService interface {
+	CreateRate(ctx context.Context, rate Rate) (int32, error)
+	ListRates(ctx context.Context, user_id, project_id, task_id int32) ([]Rate, error)
+	DeleteRate(ctx context.Context, id int32) error
+}
+
+type ratesSvc struct {
+	repo repo.RatesRepo
+}
+
+func NewRatesService(repo repo.RatesRepo) RatesService {
+	return &ratesSvc{
+		repo: repo,
+	}
+}
+
+// CreateRate adds a rate for exactly one of a person, project or task. Rates are never changed
+// in place: a new rate with a later EffectiveFrom replaces the previous one from then on.
+// A zero EffectiveFrom means now.
+func (s *ratesSvc) CreateRate(ctx context.Context, rate Rate) (int32, error) {
+	cents, err := parseCents(rate.Amount)
+	if err != nil {
+		return 0, err
+	}
+
+	arg := repo.CreateRateParams{
+		Amount:        formatCents(cents),
+		EffectiveFrom: rate.EffectiveFrom,
+		CreatedAt:     time.Now(),
+	}
+	if arg.EffectiveFrom.IsZero() {
+		arg.EffectiveFrom = arg.CreatedAt
+	}
+
+	targets := 0
+	if rate.UserID != 0 {
+		targets++
+		_, err = s.repo.GetPersonByID(ctx, rate.UserID)
+		arg.UserID = sql.NullInt32{Int32: rate.UserID, Valid: true}
+	}
+	if rate.ProjectID != 0 {
+		targets++
+		_, err = s.repo.GetProjectByID(ctx, rate.ProjectID)
+		arg.ProjectID = sql.NullInt32{Int32: rate.ProjectID, Valid: true}
+	}
+	if rate.TaskID != 0 {
+		targets++
+		_, err = s.repo.GetTaskByID(ctx, rate.TaskID)
+		arg.TaskID = sql.NullInt32{Int32: rate.TaskID, Valid: true}
+	}
+	if targets != 1 {
+		return 0, ErrInvalidRateTarget
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoResult
+		}
+		return 0, err
+	}
+
+	return s.repo.CreateRate(ctx, arg)
+}
+
+// ListRates returns the rate history matching the filter, latest first; zero values don't filter.
+func (s *ratesSvc) ListRates(ctx context.Context, user_id, project_id, task_id int32) ([]Rate, error) {
+	rates, err := s.repo.ListRates(ctx, repo.ListRatesParams{
+		UserID:    user_id,
+		ProjectID: project_id,
+		TaskID:    task_id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Rate{}
+	for _, rate := range rates {
+		result = append(result, toRate(rate))
+	}
+	return result, nil
+}
+
+// DeleteRate removes a rate entered by mistake; the previous rate applies again.
+func (s *ratesSvc) DeleteRate(ctx context.Context, id int32) error {
+	_, err := s.repo.GetRateByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoResult
+		}
+		return err
+	}
+	return s.repo.DeleteRate(ctx, id)
+}
+
+func toRate(rate repo.Rate) Rate {
+	return Rate{
+		ID:            rate.ID,
+		UserID:        rate.UserID.Int32,
+		ProjectID:     rate.ProjectID.Int32,
+		TaskID:        rate.TaskID.Int32,
+		Amount:        rate.Amount,
+		EffectiveFrom: rate.EffectiveFrom,
+		CreatedAt:     rate.CreatedAt,
+	}
+}
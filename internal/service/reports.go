@@ -0,0 +1,461 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// Fields the team report can be sorted by.
+const (
+	SortByTotal   = "total_seconds"
+	SortByCount   = "task_count"
+	SortByLongest = "longest_task_seconds"
+	SortByAverage = "average_task_seconds"
+	SortBySurname = "surname"
+	SortByName    = "name"
+)
+
+type ReportsService interface {
+	GetPersonReport(ctx context.Context, user_id int, period string, from_dt, to_dt time.Time, clip bool) (PersonReport, error)
+	GetTeamReport(ctx context.Context, filter Filter, sortBy string, desc bool, from_dt, to_dt time.Time, clip bool) ([]PersonWorkload, error)
+	GetProjectReport(ctx context.Context, client_id, project_id int32, from_dt, to_dt time.Time, clip bool) ([]ClientReport, error)
+	GetTagReport(ctx context.Context, user_id int32, from_dt, to_dt time.Time, clip bool) ([]TagReport, error)
+	GetProjectBurndown(ctx context.Context, project_id int32, period string, from_dt, to_dt time.Time, clip bool) (ProjectBurndown, error)
+	GetOvertimeReport(ctx context.Context, user_id int32, period string, from_dt, to_dt time.Time, rules OvertimeRules, clip bool) (OvertimeReport, error)
+}
+
+type reportsSvc struct {
+	repo repo.ReportsRepo
+}
+
+func NewReportsService(repo repo.ReportsRepo) ReportsService {
+	return &reportsSvc{
+		repo: repo,
+	}
+}
+
+// GetPersonReport sums the time tracked by a person into period buckets covering [from_dt, to_dt).
+// Sessions crossing a bucket boundary are split between the buckets, and buckets without
+// any tracked time are still returned with zero totals. With clip set, running sessions
+// are counted up to now.
+func (s *reportsSvc) GetPersonReport(ctx context.Context, user_id int, period string, from_dt, to_dt time.Time, clip bool) (PersonReport, error) {
+	if !validPeriod(period) {
+		return PersonReport{}, ErrInvalidPeriod
+	}
+	if !to_dt.After(from_dt) {
+		return PersonReport{}, ErrInvalidRange
+	}
+
+	_, err := s.repo.GetPersonByID(ctx, int32(user_id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PersonReport{}, ErrNoResult
+		}
+		return PersonReport{}, err
+	}
+
+	rows, err := s.repo.GetPersonReport(ctx, repo.GetPersonReportParams{
+		Period: period,
+		FromDt: from_dt,
+		ToDt:   to_dt,
+		Now:    runningUntil(clip),
+		UserID: int32(user_id),
+	})
+	if err != nil {
+		return PersonReport{}, err
+	}
+
+	report := PersonReport{
+		UserID:  int32(user_id),
+		Period:  period,
+		FromDt:  from_dt,
+		ToDt:    to_dt,
+		Buckets: []ReportBucket{},
+	}
+	index := make(map[int64]int)
+	for start := truncatePeriod(from_dt, period); start.Before(to_dt); start = nextPeriod(start, period) {
+		index[start.Unix()] = len(report.Buckets)
+		report.Buckets = append(report.Buckets, ReportBucket{
+			Start: start,
+			End:   nextPeriod(start, period),
+			Tasks: []ReportTask{},
+		})
+	}
+
+	for _, row := range rows {
+		i, ok := index[row.BucketStart.Unix()]
+		if !ok {
+			continue
+		}
+		bucket := &report.Buckets[i]
+		bucket.Tasks = append(bucket.Tasks, ReportTask{
+			TaskID:       row.TaskID,
+			Description:  row.Description,
+			TotalSeconds: row.TotalSeconds,
+			Duration:     isoDuration(row.TotalSeconds),
+			AmountCents:  row.AmountCents,
+			Amount:       formatCents(row.AmountCents),
+		})
+		bucket.TotalSeconds += row.TotalSeconds
+		bucket.AmountCents += row.AmountCents
+		report.TotalSeconds += row.TotalSeconds
+		report.AmountCents += row.AmountCents
+	}
+
+	for i := range report.Buckets {
+		report.Buckets[i].Duration = isoDuration(report.Buckets[i].TotalSeconds)
+		report.Buckets[i].Amount = formatCents(report.Buckets[i].AmountCents)
+	}
+	report.Duration = isoDuration(report.TotalSeconds)
+	report.Amount = formatCents(report.AmountCents)
+	return report, nil
+}
+
+// GetTeamReport returns the workload of every person matching the filter over [from_dt, to_dt).
+// People without tracked time in the range are included with zero totals.
+// With clip set, running sessions are counted up to now.
+func (s *reportsSvc) GetTeamReport(ctx context.Context, filter Filter, sortBy string, desc bool, from_dt, to_dt time.Time, clip bool) ([]PersonWorkload, error) {
+	if !to_dt.After(from_dt) {
+		return nil, ErrInvalidRange
+	}
+	if sortBy == "" {
+		sortBy = SortByTotal
+	}
+	if !validSort(sortBy) {
+		return nil, ErrInvalidSort
+	}
+
+	arg := repo.GetTeamReportParams{
+		Now:        runningUntil(clip),
+		FromDt:     from_dt,
+		ToDt:       to_dt,
+		Surname:    filter.Surname,
+		Name:       filter.Name,
+		Patronymic: filter.Patronymic,
+		Address:    filter.Address,
+		SortBy:     sortBy,
+		SortDesc:   desc,
+	}
+	if filter.PassportSerie != nil {
+		arg.PassportSerie = *filter.PassportSerie
+	}
+	if filter.PassportNumber != nil {
+		arg.PassportNumber = *filter.PassportNumber
+	}
+	if filter.Limit != nil {
+		arg.Limit = sql.NullInt32{
+			Int32: *filter.Limit,
+			Valid: true,
+		}
+		if filter.Offset != nil {
+			arg.Offset = (*filter.Offset - 1) * *filter.Limit
+		}
+	}
+
+	rows, err := s.repo.GetTeamReport(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []PersonWorkload{}
+	for _, row := range rows {
+		w := PersonWorkload{
+			Person: Person{
+				ID:             row.ID,
+				PassportNumber: row.PassportNumber,
+				PassportSerie:  row.PassportSerie,
+				Name:           row.Name,
+				Surname:        row.Surname,
+				Address:        row.Address,
+			},
+			TaskCount:          row.TaskCount,
+			TotalSeconds:       row.TotalSeconds,
+			Duration:           isoDuration(row.TotalSeconds),
+			LongestTaskID:      row.LongestTaskID,
+			LongestTaskSeconds: row.LongestTaskSeconds,
+			AverageTaskSeconds: row.AverageTaskSeconds,
+			AmountCents:        row.AmountCents,
+			Amount:             formatCents(row.AmountCents),
+		}
+		if row.Patronymic.Valid {
+			w.Patronymic = row.Patronymic.String
+		}
+		result = append(result, w)
+	}
+	return result, nil
+}
+
+func validSort(sortBy string) bool {
+	switch sortBy {
+	case SortByTotal, SortByCount, SortByLongest, SortByAverage, SortBySurname, SortByName:
+		return true
+	}
+	return false
+}
+
+func validPeriod(period string) bool {
+	switch period {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return true
+	}
+	return false
+}
+
+// truncatePeriod mirrors postgres date_trunc: weeks start on Monday.
+func truncatePeriod(t time.Time, period string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case PeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+func nextPeriod(t time.Time, period string) time.Time {
+	switch period {
+	case PeriodWeek:
+		return t.AddDate(0, 0, 7)
+	case PeriodMonth:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// GetProjectReport sums the time tracked over [from_dt, to_dt) per client and project,
+// optionally limited to one client or project. Clients are ordered by name and their
+// projects by time spent, longest first.
+func (s *reportsSvc) GetProjectReport(ctx context.Context, client_id, project_id int32, from_dt, to_dt time.Time, clip bool) ([]ClientReport, error) {
+	if !to_dt.After(from_dt) {
+		return nil, ErrInvalidRange
+	}
+
+	rows, err := s.repo.GetProjectReport(ctx, repo.GetProjectReportParams{
+		Now:       runningUntil(clip),
+		ToDt:      to_dt,
+		FromDt:    from_dt,
+		ClientID:  client_id,
+		ProjectID: project_id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []ClientReport{}
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].ClientID != row.ClientID {
+			result = append(result, ClientReport{
+				ClientID:   row.ClientID,
+				ClientName: row.ClientName,
+				Projects:   []ProjectReport{},
+			})
+		}
+		client := &result[len(result)-1]
+		client.Projects = append(client.Projects, ProjectReport{
+			ProjectID:    row.ProjectID,
+			ProjectName:  row.ProjectName,
+			TaskCount:    row.TaskCount,
+			TotalSeconds: row.TotalSeconds,
+			Duration:     isoDuration(row.TotalSeconds),
+			AmountCents:  row.AmountCents,
+			Amount:       formatCents(row.AmountCents),
+		})
+		client.TotalSeconds += row.TotalSeconds
+		client.AmountCents += row.AmountCents
+	}
+
+	for i := range result {
+		result[i].Duration = isoDuration(result[i].TotalSeconds)
+		result[i].Amount = formatCents(result[i].AmountCents)
+	}
+	return result, nil
+}
+
+// GetTagReport sums the time tracked over [from_dt, to_dt) per person and tag, optionally
+// limited to one person. Untagged tasks are left out.
+func (s *reportsSvc) GetTagReport(ctx context.Context, user_id int32, from_dt, to_dt time.Time, clip bool) ([]TagReport, error) {
+	if !to_dt.After(from_dt) {
+		return nil, ErrInvalidRange
+	}
+
+	rows, err := s.repo.GetTagReport(ctx, repo.GetTagReportParams{
+		Now:    runningUntil(clip),
+		ToDt:   to_dt,
+		FromDt: from_dt,
+		UserID: user_id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []TagReport{}
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].ID != row.UserID {
+			result = append(result, TagReport{
+				Person: Person{
+					ID:      row.UserID,
+					Name:    row.Name,
+					Surname: row.Surname,
+				},
+				Tags: []TagTotal{},
+			})
+		}
+		person := &result[len(result)-1]
+		person.Tags = append(person.Tags, TagTotal{
+			Tag:          row.Tag,
+			TaskCount:    row.TaskCount,
+			TotalSeconds: row.TotalSeconds,
+			Duration:     isoDuration(row.TotalSeconds),
+			AmountCents:  row.AmountCents,
+			Amount:       formatCents(row.AmountCents),
+		})
+	}
+	return result, nil
+}
+
+// GetProjectBurndown draws the budget of a project down by the time tracked on it per period
+// over [from_dt, to_dt), starting from what was already spent before from_dt.
+// With clip set, running sessions are counted up to now.
+func (s *reportsSvc) GetProjectBurndown(ctx context.Context, project_id int32, period string, from_dt, to_dt time.Time, clip bool) (ProjectBurndown, error) {
+	if !validPeriod(period) {
+		return ProjectBurndown{}, ErrInvalidPeriod
+	}
+	if !to_dt.After(from_dt) {
+		return ProjectBurndown{}, ErrInvalidRange
+	}
+
+	project, err := s.repo.GetProjectByID(ctx, project_id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ProjectBurndown{}, ErrNoResult
+		}
+		return ProjectBurndown{}, err
+	}
+	if !project.BudgetSeconds.Valid {
+		return ProjectBurndown{}, ErrNoBudget
+	}
+
+	now := runningUntil(clip)
+	spent, err := s.repo.GetProjectTrackedSeconds(ctx, repo.GetProjectTrackedSecondsParams{
+		Now:       now,
+		BeforeDt:  from_dt,
+		ProjectID: project_id,
+	})
+	if err != nil {
+		return ProjectBurndown{}, err
+	}
+	rows, err := s.repo.GetProjectBurndown(ctx, repo.GetProjectBurndownParams{
+		Period:    period,
+		FromDt:    from_dt,
+		ToDt:      to_dt,
+		Now:       now,
+		ProjectID: project_id,
+	})
+	if err != nil {
+		return ProjectBurndown{}, err
+	}
+	tracked := make(map[int64]int64)
+	for _, row := range rows {
+		tracked[row.BucketStart.Unix()] = row.TotalSeconds
+	}
+
+	budget := project.BudgetSeconds.Int64
+	result := ProjectBurndown{
+		ProjectID:     project_id,
+		Period:        period,
+		FromDt:        from_dt,
+		ToDt:          to_dt,
+		BudgetSeconds: budget,
+		Budget:        isoDuration(budget),
+		Points:        []BurndownPoint{},
+	}
+	for start := truncatePeriod(from_dt, period); start.Before(to_dt); start = nextPeriod(start, period) {
+		seconds := tracked[start.Unix()]
+		spent += seconds
+		result.Points = append(result.Points, BurndownPoint{
+			Start:            start,
+			End:              nextPeriod(start, period),
+			TrackedSeconds:   seconds,
+			SpentSeconds:     spent,
+			RemainingSeconds: budget - spent,
+			Remaining:        isoDuration(budget - spent),
+		})
+	}
+	return result, nil
+}
+
+// GetOvertimeReport compares the time tracked by a person over [from_dt, to_dt) with their
+// schedule. Time is classified per day and summed into period buckets, see dailyOvertime.
+// With clip set, running sessions are counted up to now.
+func (s *reportsSvc) GetOvertimeReport(ctx context.Context, user_id int32, period string, from_dt, to_dt time.Time, rules OvertimeRules, clip bool) (OvertimeReport, error) {
+	if !validPeriod(period) {
+		return OvertimeReport{}, ErrInvalidPeriod
+	}
+	if !to_dt.After(from_dt) {
+		return OvertimeReport{}, ErrInvalidRange
+	}
+	parsed, err := parseOvertimeRules(&rules)
+	if err != nil {
+		return OvertimeReport{}, err
+	}
+
+	person, err := s.repo.GetPersonByID(ctx, user_id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return OvertimeReport{}, ErrNoResult
+		}
+		return OvertimeReport{}, err
+	}
+	sessions, err := s.repo.GetPersonSessions(ctx, repo.GetPersonSessionsParams{
+		FromDt: from_dt,
+		Now:    runningUntil(clip),
+		ToDt:   to_dt,
+		UserID: user_id,
+	})
+	if err != nil {
+		return OvertimeReport{}, err
+	}
+
+	report := OvertimeReport{
+		UserID:   user_id,
+		Period:   period,
+		FromDt:   from_dt,
+		ToDt:     to_dt,
+		Schedule: toSchedule(person),
+		Rules:    rules,
+		Buckets:  []OvertimeBucket{},
+	}
+	for _, day := range dailyOvertime(person, parsed, sessions, from_dt, to_dt) {
+		start := truncatePeriod(day.Start, period)
+		if len(report.Buckets) == 0 || !report.Buckets[len(report.Buckets)-1].Start.Equal(start) {
+			report.Buckets = append(report.Buckets, OvertimeBucket{
+				Start: start,
+				End:   nextPeriod(start, period),
+			})
+		}
+		report.Buckets[len(report.Buckets)-1].add(day.OvertimeTotals)
+		report.add(day.OvertimeTotals)
+	}
+	return report, nil
+}
+
+// runningUntil is the end time given to open sessions by the report queries.
+// Without clip it is NULL, which leaves running sessions out of the reports.
+func runningUntil(clip bool) sql.NullTime {
+	return sql.NullTime{
+		Time:  time.Now(),
+		Valid: clip,
+	}
+}
@@ -4,16 +4,34 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/gogoalish/timetracker/internal/ical"
 	"github.com/gogoalish/timetracker/internal/repo"
+	"github.com/lib/pq"
 )
 
 type TasksService interface {
-	CreateTask(ctx context.Context, user_id int, description string) (int32, error)
-	StartTask(ctx context.Context, id int) error
+	CreateTask(ctx context.Context, user_id int, description string, project_id int32) (int32, error)
+	StartTask(ctx context.Context, id int, switchOver bool) error
 	EndTask(ctx context.Context, id int) error
-	GetOrderedTasks(ctx context.Context, user_id int, from_dt, to_dt time.Time) ([]Task, error)
+	PauseTask(ctx context.Context, id int) error
+	ResumeTask(ctx context.Context, id int, switchOver bool) error
+	CancelTask(ctx context.Context, id int) error
+	GetCurrentTask(ctx context.Context, user_id int) (CurrentTask, error)
+	GetCalendar(ctx context.Context, user_id int, from_dt, to_dt time.Time) ([]ical.Event, error)
+	CreateManualTask(ctx context.Context, task ManualTask) (int32, error)
+	EditTask(ctx context.Context, edit TaskEdit) error
+	GetOrderedTasks(ctx context.Context, user_id int, from_dt, to_dt time.Time, clip bool, tag string) ([]Task, error)
+	GetTask(ctx context.Context, id int) (Task, error)
+	ListTasks(ctx context.Context, filter TaskFilter) ([]Task, error)
+	ListOverEstimate(ctx context.Context, user_id, project_id int32) ([]Task, error)
+	DeleteTask(ctx context.Context, id int) error
+	AddTag(ctx context.Context, id int, tag string) error
+	RemoveTag(ctx context.Context, id int, tag string) error
+	ListTags(ctx context.Context) ([]string, error)
 }
 
 type tasksSvc struct {
@@ -26,50 +44,842 @@ func NewTasksService(repo repo.TasksRepo) TasksService {
 	}
 }
 
-func (s *tasksSvc) CreateTask(ctx context.Context, user_id int, description string) (int32, error) {
+// CreateTask creates a task for the person, attached to a project unless project_id is 0.
+// Tasks can't be created while the current time is locked for the person.
+func (s *tasksSvc) CreateTask(ctx context.Context, user_id int, description string, project_id int32) (int32, error) {
+	now := time.Now()
+	if err := checkLocked(ctx, s.repo, int32(user_id), now, now); err != nil {
+		return 0, err
+	}
+	projectID, err := getProjectID(ctx, s.repo, project_id)
+	if err != nil {
+		return 0, err
+	}
+
 	return s.repo.CreateTask(ctx, repo.CreateTaskParams{
 		UserID:      int32(user_id),
 		Description: description,
-		CreatedAt:   time.Now(),
+		CreatedAt:   now,
+		ProjectID:   projectID,
+	})
+}
+
+// StartTask moves a created task (or a paused one) to running and opens a new work session.
+// A person can only have one running task: if another one is running, StartTask fails with
+// ErrAnotherTaskRunning unless switchOver is set, in which case the other task is paused
+// in the same transaction.
+func (s *tasksSvc) StartTask(ctx context.Context, id int, switchOver bool) error {
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		return runTask(ctx, r, task, switchOver, time.Now())
+	})
+}
+
+func (s *tasksSvc) PauseTask(ctx context.Context, id int) error {
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		return stopTask(ctx, r, task, repo.TaskStatusPaused, time.Now())
+	})
+}
+
+// ResumeTask opens a new work session for a paused task, following the same
+// one-running-task rule as StartTask.
+func (s *tasksSvc) ResumeTask(ctx context.Context, id int, switchOver bool) error {
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		if task.Status == repo.TaskStatusCreated {
+			return ErrTaskNotStarted
+		}
+		return runTask(ctx, r, task, switchOver, time.Now())
 	})
 }
 
-func (s *tasksSvc) StartTask(ctx context.Context, id int) error {
-	_, err := s.repo.GetTaskByID(ctx, int32(id))
+func (s *tasksSvc) EndTask(ctx context.Context, id int) error {
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		return stopTask(ctx, r, task, repo.TaskStatusDone, time.Now())
+	})
+}
+
+// CancelTask abandons a task that is not finished yet. The tracked sessions are kept
+// but the task never gets an end_dt, so it doesn't show up in the ordered tasks.
+func (s *tasksSvc) CancelTask(ctx context.Context, id int) error {
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		return stopTask(ctx, r, task, repo.TaskStatusCancelled, time.Now())
+	})
+}
+
+func (s *tasksSvc) GetCurrentTask(ctx context.Context, user_id int) (CurrentTask, error) {
+	task, err := s.repo.GetRunningTaskByUserID(ctx, int32(user_id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return ErrNoResult
+			return CurrentTask{}, ErrNoResult
 		}
-		return err
+		return CurrentTask{}, err
 	}
 
-	return s.repo.SetTaskStartDate(ctx, repo.SetTaskStartDateParams{
-		ID: int32(id),
-		StartDt: sql.NullTime{
-			Time:  time.Now(),
-			Valid: true,
+	entries, err := s.repo.ListTimeEntriesByTaskID(ctx, task.ID)
+	if err != nil {
+		return CurrentTask{}, err
+	}
+
+	now := time.Now()
+	result := CurrentTask{
+		Task: Task{
+			ID:          task.ID,
+			UserID:      task.UserID,
+			Description: task.Description,
+			StartDt:     task.StartDt.Time,
+			CreatedAt:   task.CreatedAt,
+			Status:      string(task.Status),
 		},
+	}
+	var elapsed time.Duration
+	for _, entry := range entries {
+		if !entry.EndDt.Valid {
+			result.SessionStartDt = entry.StartDt
+			elapsed += now.Sub(entry.StartDt)
+			continue
+		}
+		elapsed += entry.EndDt.Time.Sub(entry.StartDt)
+	}
+	result.ElapsedSeconds = int64(elapsed.Seconds())
+	result.setDuration(result.ElapsedSeconds)
+	result.setEstimate(task.EstimateSeconds)
+	return result, nil
+}
+
+// GetTask returns a task with the time tracked on it so far, counting a running
+// session up to now.
+func (s *tasksSvc) GetTask(ctx context.Context, id int) (Task, error) {
+	task, err := getTask(ctx, s.repo, id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	entries, err := s.repo.ListTimeEntriesByTaskID(ctx, task.ID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	tags, err := s.repo.ListTagsByTaskID(ctx, task.ID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	now := time.Now()
+	var elapsed time.Duration
+	for _, entry := range entries {
+		if !entry.EndDt.Valid {
+			elapsed += now.Sub(entry.StartDt)
+			continue
+		}
+		elapsed += entry.EndDt.Time.Sub(entry.StartDt)
+	}
+
+	result := Task{
+		ID:          task.ID,
+		UserID:      task.UserID,
+		Description: task.Description,
+		StartDt:     task.StartDt.Time,
+		EndDt:       task.EndDt.Time,
+		CreatedAt:   task.CreatedAt,
+		Status:      string(task.Status),
+		ProjectID:   task.ProjectID.Int32,
+		Billable:    task.Billable,
+		Tags:        tags,
+	}
+	result.setDuration(int64(elapsed.Seconds()))
+	result.setEstimate(task.EstimateSeconds)
+	return result, nil
+}
+
+// ListTasks returns the tasks matching the filter regardless of their status,
+// newest first. Query is matched against the description with full-text search.
+func (s *tasksSvc) ListTasks(ctx context.Context, filter TaskFilter) ([]Task, error) {
+	arg := repo.ListTasksParams{
+		Now:         time.Now(),
+		UserID:      filter.UserID,
+		ProjectID:   filter.ProjectID,
+		Query:       filter.Query,
+		RunningOnly: filter.RunningOnly,
+		Tag:         normalizeTag(filter.Tag),
+	}
+	if filter.Status != "" {
+		status := repo.TaskStatus(filter.Status)
+		if !validStatus(status) {
+			return nil, ErrInvalidStatus
+		}
+		arg.Status = repo.NullTaskStatus{
+			TaskStatus: status,
+			Valid:      true,
+		}
+	}
+	if filter.CreatedFrom != nil {
+		arg.CreatedFrom = sql.NullTime{
+			Time:  *filter.CreatedFrom,
+			Valid: true,
+		}
+	}
+	if filter.CreatedTo != nil {
+		arg.CreatedTo = sql.NullTime{
+			Time:  *filter.CreatedTo,
+			Valid: true,
+		}
+	}
+	if filter.Limit != nil {
+		arg.Limit = sql.NullInt32{
+			Int32: *filter.Limit,
+			Valid: true,
+		}
+		if filter.Offset != nil {
+			arg.Offset = (*filter.Offset - 1) * *filter.Limit
+		}
+	}
+
+	tasks, err := s.repo.ListTasks(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Task{}
+	for _, task := range tasks {
+		t := Task{
+			ID:          task.ID,
+			UserID:      task.UserID,
+			Description: task.Description,
+			StartDt:     task.StartDt.Time,
+			EndDt:       task.EndDt.Time,
+			CreatedAt:   task.CreatedAt,
+			Status:      string(task.Status),
+			ProjectID:   task.ProjectID.Int32,
+			Billable:    task.Billable,
+		}
+		t.setDuration(task.TotalSeconds)
+		t.setEstimate(task.EstimateSeconds)
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// ListOverEstimate returns the estimated tasks whose tracked time, counting running sessions
+// up to now, exceeds the estimate, the largest overrun first. Zero ids don't filter.
+func (s *tasksSvc) ListOverEstimate(ctx context.Context, user_id, project_id int32) ([]Task, error) {
+	tasks, err := s.repo.ListTasksOverEstimate(ctx, repo.ListTasksOverEstimateParams{
+		Now:       time.Now(),
+		UserID:    user_id,
+		ProjectID: project_id,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Task{}
+	for _, task := range tasks {
+		t := Task{
+			ID:          task.ID,
+			UserID:      task.UserID,
+			Description: task.Description,
+			StartDt:     task.StartDt.Time,
+			EndDt:       task.EndDt.Time,
+			CreatedAt:   task.CreatedAt,
+			Status:      string(task.Status),
+			ProjectID:   task.ProjectID.Int32,
+			Billable:    task.Billable,
+		}
+		t.setDuration(task.TotalSeconds)
+		t.setEstimate(task.EstimateSeconds)
+		result = append(result, t)
+	}
+	return result, nil
 }
 
-func (s *tasksSvc) EndTask(ctx context.Context, id int) error {
-	_, err := s.repo.GetTaskByID(ctx, int32(id))
+// DeleteTask hides a task from every listing and report. The row and its sessions are kept;
+// a running task is paused first so the person can start another one.
+func (s *tasksSvc) DeleteTask(ctx context.Context, id int) error {
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := checkTaskLocked(ctx, r, task, now); err != nil {
+			return err
+		}
+		if task.Status == repo.TaskStatusRunning {
+			if err := stopTask(ctx, r, task, repo.TaskStatusPaused, now); err != nil {
+				return err
+			}
+		}
+		return r.SoftDeleteTask(ctx, repo.SoftDeleteTaskParams{
+			DeletedAt: sql.NullTime{Time: now, Valid: true},
+			ID:        task.ID,
+		})
+	})
+}
+
+// AddTag attaches a tag to a task, creating the tag on first use. Tags are
+// case-insensitive and attaching a tag twice is a no-op.
+func (s *tasksSvc) AddTag(ctx context.Context, id int, tag string) error {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return ErrEmptyTag
+	}
+
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, id)
+		if err != nil {
+			return err
+		}
+
+		tagID, err := r.UpsertTag(ctx, tag)
+		if err != nil {
+			return err
+		}
+		return r.AddTaskTag(ctx, repo.AddTaskTagParams{
+			TaskID: task.ID,
+			TagID:  tagID,
+		})
+	})
+}
+
+// RemoveTag detaches a tag from a task; the tag itself is kept for other tasks.
+func (s *tasksSvc) RemoveTag(ctx context.Context, id int, tag string) error {
+	task, err := getTask(ctx, s.repo, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := s.repo.RemoveTaskTag(ctx, repo.RemoveTaskTagParams{
+		TaskID: task.ID,
+		Name:   normalizeTag(tag),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNoResult
+	}
+	return nil
+}
+
+func (s *tasksSvc) ListTags(ctx context.Context) ([]string, error) {
+	tags, err := s.repo.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, tag := range tags {
+		result = append(result, tag.Name)
+	}
+	return result, nil
+}
+
+// GetCalendar returns one calendar event per work session of the person's tasks overlapping
+// [from_dt, to_dt). Tasks without sessions are shown as a single event spanning start_dt to end_dt.
+// UIDs only depend on task and session IDs, so calendar clients update events instead of duplicating them.
+func (s *tasksSvc) GetCalendar(ctx context.Context, user_id int, from_dt, to_dt time.Time) ([]ical.Event, error) {
+	if !to_dt.After(from_dt) {
+		return nil, ErrInvalidRange
+	}
+
+	now := time.Now()
+	rows, err := s.repo.GetCalendarEntriesByUserID(ctx, repo.GetCalendarEntriesByUserIDParams{
+		UserID: int32(user_id),
+		FromDt: from_dt,
+		ToDt:   to_dt,
+		Now:    now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := []ical.Event{}
+	for _, row := range rows {
+		e := ical.Event{
+			UID:         fmt.Sprintf("task-%d@timetracker", row.TaskID),
+			Summary:     row.Description,
+			Description: fmt.Sprintf("Task #%d, %s", row.TaskID, row.Status),
+			Start:       row.TaskStartDt.Time,
+			End:         row.TaskEndDt.Time,
+		}
+		end := row.TaskEndDt
+		if row.EntryID.Valid {
+			e.UID = fmt.Sprintf("task-%d-session-%d@timetracker", row.TaskID, row.EntryID.Int32)
+			e.Start = row.StartDt.Time
+			e.End = row.EndDt.Time
+			end = row.EndDt
+		}
+		if !end.Valid {
+			e.End = now
+			e.Tentative = true
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// auditLayout formats timestamps in audit notes.
+const auditLayout = "2006-01-02 15:04:05"
+
+// CreateManualTask records a finished task with explicit times, for work that was done
+// without starting the timer.
+func (s *tasksSvc) CreateManualTask(ctx context.Context, task ManualTask) (int32, error) {
+	now := time.Now()
+	if err := validateInterval(task.StartDt, task.EndDt, now); err != nil {
+		return 0, err
+	}
+
+	var id int32
+	err := s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		_, err := r.GetPersonByID(ctx, task.UserID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNoResult
+			}
+			return err
+		}
+		if err := checkLocked(ctx, r, task.UserID, task.StartDt, task.EndDt); err != nil {
+			return err
+		}
+		if err := checkOverlap(ctx, r, task.UserID, 0, task.StartDt, task.EndDt, now); err != nil {
+			return err
+		}
+		projectID, err := getProjectID(ctx, r, task.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		id, err = r.CreateFinishedTask(ctx, repo.CreateFinishedTaskParams{
+			UserID:      task.UserID,
+			Description: task.Description,
+			StartDt:     sql.NullTime{Time: task.StartDt, Valid: true},
+			EndDt:       sql.NullTime{Time: task.EndDt, Valid: true},
+			CreatedAt:   now,
+			ProjectID:   projectID,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = r.CreateClosedTimeEntry(ctx, repo.CreateClosedTimeEntryParams{
+			TaskID:  id,
+			StartDt: task.StartDt,
+			EndDt:   sql.NullTime{Time: task.EndDt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return r.CreateTaskAudit(ctx, repo.CreateTaskAuditParams{
+			TaskID:    id,
+			ChangedBy: task.ChangedBy,
+			ChangedAt: now,
+			Note:      fmt.Sprintf("created manually: %s - %s", task.StartDt.Format(auditLayout), task.EndDt.Format(auditLayout)),
+		})
+	})
+	return id, err
+}
+
+// EditTask changes the description, project, billable flag, estimate and/or the times of a task and records the change in
+// the audit log. New times replace all the work sessions of the task with a single one,
+// and finish it unless it was cancelled; running tasks must be stopped first. Tasks touching
+// a week of an approved timesheet can't be edited.
+func (s *tasksSvc) EditTask(ctx context.Context, edit TaskEdit) error {
+	now := time.Now()
+	return s.repo.ExecTx(ctx, func(r repo.TasksRepo) error {
+		task, err := getTask(ctx, r, int(edit.ID))
+		if err != nil {
+			return err
+		}
+		if err := checkTaskLocked(ctx, r, task, now); err != nil {
+			return err
+		}
+
+		arg := repo.UpdateTaskTimesParams{
+			ID:              task.ID,
+			Description:     task.Description,
+			StartDt:         task.StartDt,
+			EndDt:           task.EndDt,
+			Status:          task.Status,
+			ProjectID:       task.ProjectID,
+			Billable:        task.Billable,
+			EstimateSeconds: task.EstimateSeconds,
+		}
+		var notes []string
+		if edit.Description != nil && *edit.Description != task.Description {
+			notes = append(notes, fmt.Sprintf("description: %q -> %q", task.Description, *edit.Description))
+			arg.Description = *edit.Description
+		}
+		if edit.ProjectID != nil && *edit.ProjectID != task.ProjectID.Int32 {
+			arg.ProjectID, err = getProjectID(ctx, r, *edit.ProjectID)
+			if err != nil {
+				return err
+			}
+			notes = append(notes, fmt.Sprintf("project_id: %d -> %d", task.ProjectID.Int32, *edit.ProjectID))
+		}
+		if edit.Billable != nil && *edit.Billable != task.Billable {
+			notes = append(notes, fmt.Sprintf("billable: %t -> %t", task.Billable, *edit.Billable))
+			arg.Billable = *edit.Billable
+		}
+		if edit.EstimateSeconds != nil && *edit.EstimateSeconds != task.EstimateSeconds.Int64 {
+			if *edit.EstimateSeconds < 0 {
+				return ErrInvalidEstimate
+			}
+			notes = append(notes, fmt.Sprintf("estimate: %s -> %s", formatAuditEstimate(task.EstimateSeconds.Int64), formatAuditEstimate(*edit.EstimateSeconds)))
+			arg.EstimateSeconds = sql.NullInt64{Int64: *edit.EstimateSeconds, Valid: *edit.EstimateSeconds > 0}
+		}
+
+		if edit.StartDt != nil || edit.EndDt != nil {
+			if task.Status == repo.TaskStatusRunning {
+				return ErrTaskAlreadyRunning
+			}
+			if edit.StartDt != nil {
+				arg.StartDt = sql.NullTime{Time: *edit.StartDt, Valid: true}
+			}
+			if edit.EndDt != nil {
+				arg.EndDt = sql.NullTime{Time: *edit.EndDt, Valid: true}
+			}
+			if !arg.StartDt.Valid || !arg.EndDt.Valid {
+				return ErrInvalidInterval
+			}
+			if err := validateInterval(arg.StartDt.Time, arg.EndDt.Time, now); err != nil {
+				return err
+			}
+			if err := checkLocked(ctx, r, task.UserID, arg.StartDt.Time, arg.EndDt.Time); err != nil {
+				return err
+			}
+			if err := checkOverlap(ctx, r, task.UserID, task.ID, arg.StartDt.Time, arg.EndDt.Time, now); err != nil {
+				return err
+			}
+			if task.Status != repo.TaskStatusCancelled {
+				arg.Status = repo.TaskStatusDone
+			}
+
+			notes = append(notes, fmt.Sprintf("start_dt: %s -> %s", formatAuditTime(task.StartDt), arg.StartDt.Time.Format(auditLayout)))
+			notes = append(notes, fmt.Sprintf("end_dt: %s -> %s", formatAuditTime(task.EndDt), arg.EndDt.Time.Format(auditLayout)))
+			if arg.Status != task.Status {
+				notes = append(notes, fmt.Sprintf("status: %s -> %s", task.Status, arg.Status))
+			}
+
+			if err := r.DeleteTimeEntriesByTaskID(ctx, task.ID); err != nil {
+				return err
+			}
+			_, err = r.CreateClosedTimeEntry(ctx, repo.CreateClosedTimeEntryParams{
+				TaskID:  task.ID,
+				StartDt: arg.StartDt.Time,
+				EndDt:   arg.EndDt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(notes) == 0 {
+			return nil
+		}
+		if err := r.UpdateTaskTimes(ctx, arg); err != nil {
+			return err
+		}
+		return r.CreateTaskAudit(ctx, repo.CreateTaskAuditParams{
+			TaskID:    task.ID,
+			ChangedBy: edit.ChangedBy,
+			ChangedAt: now,
+			Note:      strings.Join(notes, "; "),
+		})
+	})
+}
+
+// validateInterval checks a finished interval. Times are local wall clock times, like
+// time.Now() and the stored timestamps, so end is compared with now as is.
+func validateInterval(start, end, now time.Time) error {
+	if !start.Before(end) {
+		return ErrInvalidInterval
+	}
+	if end.After(now) {
+		return ErrEndInFuture
+	}
+	return nil
+}
+
+// checkOverlap fails if [start, end) intersects a work session of another task of the person.
+// Open sessions count as running up to now.
+func checkOverlap(ctx context.Context, r repo.TasksRepo, user_id, task_id int32, start, end, now time.Time) error {
+	count, err := r.CountOverlappingTimeEntries(ctx, repo.CountOverlappingTimeEntriesParams{
+		UserID:  user_id,
+		TaskID:  task_id,
+		StartDt: start,
+		EndDt:   end,
+		Now:     now,
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrOverlap
+	}
+	return nil
+}
+
+// checkLocked fails with ErrPeriodLocked when [start, end] touches a period locked for
+// the person or for everyone, or a week of the person covered by an approved timesheet.
+func checkLocked(ctx context.Context, r repo.TasksRepo, user_id int32, start, end time.Time) error {
+	locks, err := r.CountPeriodLocks(ctx, repo.CountPeriodLocksParams{
+		UserID: sql.NullInt32{Int32: user_id, Valid: true},
+		FromDt: start,
+		ToDt:   end,
+	})
+	if err != nil {
+		return err
+	}
+	timesheets, err := r.CountApprovedTimesheets(ctx, repo.CountApprovedTimesheetsParams{
+		UserID: user_id,
+		FromDt: start,
+		ToDt:   end,
+	})
+	if err != nil {
+		return err
+	}
+	if locks+timesheets > 0 {
+		return ErrPeriodLocked
+	}
+	return nil
+}
+
+// checkTaskLocked checks the time the task already spans, from its first start
+// up to its end, or up to now while it is not finished.
+func checkTaskLocked(ctx context.Context, r repo.TasksRepo, task repo.Task, now time.Time) error {
+	if !task.StartDt.Valid {
+		return nil
+	}
+	end := now
+	if task.EndDt.Valid {
+		end = task.EndDt.Time
+	}
+	return checkLocked(ctx, r, task.UserID, task.StartDt.Time, end)
+}
+
+func formatAuditTime(t sql.NullTime) string {
+	if !t.Valid {
+		return "none"
+	}
+	return t.Time.Format(auditLayout)
+}
+
+// formatAuditEstimate renders an estimate for an audit note, "none" for no estimate.
+func formatAuditEstimate(seconds int64) string {
+	if seconds == 0 {
+		return "none"
+	}
+	return isoDuration(seconds)
+}
+
+// normalizeTag trims and lowercases a tag name, so tags differing only in case are the same tag.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// isUniqueViolation tells whether err is Postgres rejecting a write that breaks the named unique index.
+func isUniqueViolation(err error, index string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == index
+}
+
+// getProjectID checks that the project exists, mapping 0 to no project.
+func getProjectID(ctx context.Context, r repo.TasksRepo, id int32) (sql.NullInt32, error) {
+	if id == 0 {
+		return sql.NullInt32{}, nil
+	}
+	_, err := r.GetProjectByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return ErrNoResult
+			return sql.NullInt32{}, ErrNoResult
+		}
+		return sql.NullInt32{}, err
+	}
+	return sql.NullInt32{Int32: id, Valid: true}, nil
+}
+
+func getTask(ctx context.Context, r repo.TasksRepo, id int) (repo.Task, error) {
+	task, err := r.GetTaskByID(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.Task{}, ErrNoResult
+		}
+		return repo.Task{}, err
+	}
+	return task, nil
+}
+
+// runTask moves the task to running and opens a new work session starting at now.
+func runTask(ctx context.Context, r repo.TasksRepo, task repo.Task, switchOver bool, now time.Time) error {
+	if err := checkTransition(task.Status, repo.TaskStatusRunning); err != nil {
+		return err
+	}
+	if err := checkLocked(ctx, r, task.UserID, now, now); err != nil {
+		return err
+	}
+
+	running, err := r.GetRunningTaskByUserID(ctx, task.UserID)
+	switch {
+	case err == nil:
+		if !switchOver {
+			return ErrAnotherTaskRunning
 		}
+		err = stopTask(ctx, r, running, repo.TaskStatusPaused, now)
+		if err != nil {
+			return err
+		}
+	case !errors.Is(err, sql.ErrNoRows):
 		return err
 	}
-	return s.repo.SetTaskEndDate(ctx, repo.SetTaskEndDateParams{
-		ID: int32(id),
-		EndDt: sql.NullTime{
-			Time:  time.Now(),
-			Valid: true,
-		},
+
+	// start_dt keeps the first start of the task, later sessions live in time_entries
+	if !task.StartDt.Valid {
+		err = r.SetTaskStartDate(ctx, repo.SetTaskStartDateParams{
+			ID: task.ID,
+			StartDt: sql.NullTime{
+				Time:  now,
+				Valid: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = r.CreateTimeEntry(ctx, repo.CreateTimeEntryParams{
+		TaskID:  task.ID,
+		StartDt: now,
 	})
+	if err != nil {
+		return err
+	}
+
+	err = r.SetTaskStatus(ctx, repo.SetTaskStatusParams{
+		ID:     task.ID,
+		Status: repo.TaskStatusRunning,
+	})
+	// a concurrent start of another task of the person won the race past the check above
+	if isUniqueViolation(err, "tasks_one_running_per_user") {
+		return ErrAnotherTaskRunning
+	}
+	return err
 }
 
-func (s *tasksSvc) GetOrderedTasks(ctx context.Context, user_id int, from_dt, to_dt time.Time) ([]Task, error) {
+// stopTask closes the open work session of the task, if any, and moves it to status.
+// Finishing the task as done also records its end_dt.
+func stopTask(ctx context.Context, r repo.TasksRepo, task repo.Task, status repo.TaskStatus, now time.Time) error {
+	if err := checkTransition(task.Status, status); err != nil {
+		return err
+	}
+
+	// closing the open session writes time from its start up to now
+	start := now
+	if task.Status == repo.TaskStatusRunning {
+		open, err := r.GetOpenTimeEntryByTaskID(ctx, task.ID)
+		switch {
+		case err == nil:
+			start = open.StartDt
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+	}
+	if err := checkLocked(ctx, r, task.UserID, start, now); err != nil {
+		return err
+	}
+
+	end := sql.NullTime{
+		Time:  now,
+		Valid: true,
+	}
+	err := r.CloseTimeEntry(ctx, repo.CloseTimeEntryParams{
+		TaskID: task.ID,
+		EndDt:  end,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status == repo.TaskStatusDone {
+		err = r.SetTaskEndDate(ctx, repo.SetTaskEndDateParams{
+			ID:    task.ID,
+			EndDt: end,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.SetTaskStatus(ctx, repo.SetTaskStatusParams{
+		ID:     task.ID,
+		Status: status,
+	})
+}
+
+// taskTransitions lists the statuses a task may move to from each status.
+// done and cancelled are final.
+var taskTransitions = map[repo.TaskStatus][]repo.TaskStatus{
+	repo.TaskStatusCreated: {repo.TaskStatusRunning, repo.TaskStatusCancelled},
+	repo.TaskStatusRunning: {repo.TaskStatusPaused, repo.TaskStatusDone, repo.TaskStatusCancelled},
+	repo.TaskStatusPaused:  {repo.TaskStatusRunning, repo.TaskStatusDone, repo.TaskStatusCancelled},
+}
+
+func validStatus(status repo.TaskStatus) bool {
+	switch status {
+	case repo.TaskStatusCreated, repo.TaskStatusRunning, repo.TaskStatusPaused, repo.TaskStatusDone, repo.TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func checkTransition(from, to repo.TaskStatus) error {
+	for _, allowed := range taskTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+
+	switch from {
+	case repo.TaskStatusDone, repo.TaskStatusCancelled:
+		return ErrTaskFinished
+	case repo.TaskStatusCreated:
+		return ErrTaskNotStarted
+	case repo.TaskStatusRunning:
+		return ErrTaskAlreadyRunning
+	case repo.TaskStatusPaused:
+		return ErrTaskNotRunning
+	}
+	return ErrInvalidTransition
+}
+
+// GetOrderedTasks returns the person's tasks ordered by tracked time, longest first.
+// By default only tasks finished within [from_dt, to_dt] are returned. With clip set, every
+// task with a session overlapping the range is returned with its time clipped to the range,
+// counting running sessions up to now. A non-empty tag keeps only the tasks carrying it.
+func (s *tasksSvc) GetOrderedTasks(ctx context.Context, user_id int, from_dt, to_dt time.Time, clip bool, tag string) ([]Task, error) {
+	tag = normalizeTag(tag)
+	if clip {
+		return s.getClippedTasks(ctx, user_id, from_dt, to_dt, tag)
+	}
+
 	tasks, err := s.repo.GetOrderedTasksByUserID(ctx, repo.GetOrderedTasksByUserIDParams{
 		UserID: int32(user_id),
 		StartDt: sql.NullTime{
@@ -80,6 +890,7 @@ func (s *tasksSvc) GetOrderedTasks(ctx context.Context, user_id int, from_dt, to
 			Time:  to_dt,
 			Valid: true,
 		},
+		Tag: tag,
 	})
 	if err != nil {
 		return nil, err
@@ -93,10 +904,45 @@ func (s *tasksSvc) GetOrderedTasks(ctx context.Context, user_id int, from_dt, to
 			StartDt:     task.StartDt.Time,
 			EndDt:       task.EndDt.Time,
 			CreatedAt:   task.CreatedAt,
+			Status:      string(task.Status),
 			UserID:      int32(user_id),
-			Hours:       int(task.Hours),
-			Minutes:     int(task.Minutes),
+			Billable:    task.Billable,
+		}
+		t.setDuration(task.TotalSeconds)
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func (s *tasksSvc) getClippedTasks(ctx context.Context, user_id int, from_dt, to_dt time.Time, tag string) ([]Task, error) {
+	if !to_dt.After(from_dt) {
+		return nil, ErrInvalidRange
+	}
+
+	tasks, err := s.repo.GetClippedTasksByUserID(ctx, repo.GetClippedTasksByUserIDParams{
+		Now:    time.Now(),
+		ToDt:   to_dt,
+		FromDt: from_dt,
+		UserID: int32(user_id),
+		Tag:    tag,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Task
+	for _, task := range tasks {
+		t := Task{
+			ID:          task.ID,
+			Description: task.Description,
+			StartDt:     task.StartDt.Time,
+			EndDt:       task.EndDt.Time,
+			CreatedAt:   task.CreatedAt,
+			Status:      string(task.Status),
+			UserID:      task.UserID,
+			Billable:    task.Billable,
 		}
+		t.setDuration(task.TotalSeconds)
 		result = append(result, t)
 	}
 	return result, nil
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to repo.TaskStatus
+		want     error
+	}{
+		{from: repo.TaskStatusCreated, to: repo.TaskStatusRunning},
+		{from: repo.TaskStatusCreated, to: repo.TaskStatusCancelled},
+		{from: repo.TaskStatusCreated, to: repo.TaskStatusPaused, want: ErrTaskNotStarted},
+		{from: repo.TaskStatusCreated, to: repo.TaskStatusDone, want: ErrTaskNotStarted},
+		{from: repo.TaskStatusRunning, to: repo.TaskStatusPaused},
+		{from: repo.TaskStatusRunning, to: repo.TaskStatusDone},
+		{from: repo.TaskStatusRunning, to: repo.TaskStatusCancelled},
+		{from: repo.TaskStatusRunning, to: repo.TaskStatusRunning, want: ErrTaskAlreadyRunning},
+		{from: repo.TaskStatusPaused, to: repo.TaskStatusRunning},
+		{from: repo.TaskStatusPaused, to: repo.TaskStatusDone},
+		{from: repo.TaskStatusPaused, to: repo.TaskStatusPaused, want: ErrTaskNotRunning},
+		{from: repo.TaskStatusDone, to: repo.TaskStatusRunning, want: ErrTaskFinished},
+		{from: repo.TaskStatusCancelled, to: repo.TaskStatusRunning, want: ErrTaskFinished},
+		{from: repo.TaskStatusDone, to: repo.TaskStatusCancelled, want: ErrTaskFinished},
+		{from: "unknown", to: repo.TaskStatusRunning, want: ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s to %s", tt.from, tt.to), func(t *testing.T) {
+			if err := checkTransition(tt.from, tt.to); !errors.Is(err, tt.want) {
+				t.Errorf("checkTransition() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gogoalish/timetracker/internal/repo"
+)
+
+// TimesheetsService runs the weekly approval workflow: a person submits the timesheet of a week,
+// a manager approves or rejects it, and a rejected timesheet can be submitted again.
+type TimesheetsService interface {
+	CreateTimesheet(ctx context.Context, user_id int32, week time.Time) (int32, error)
+	GetTimesheet(ctx context.Context, id int32) (Timesheet, error)
+	ListTimesheets(ctx context.Context, user_id int32, status string) ([]Timesheet, error)
+	SubmitTimesheet(ctx context.Context, id int32) error
+	ApproveTimesheet(ctx context.Context, id int32, reviewedBy, comment string) error
+	RejectTimesheet(ctx context.Context, id int32, reviewedBy, comment string) error
+}
+
+type timesheetsSvc struct {
+	repo repo.TimesheetsRepo
+}
+
+func NewTimesheetsService(repo repo.TimesheetsRepo) TimesheetsService {
+	return &timesheetsSvc{
+		repo: repo,
+	}
+}
+
+// CreateTimesheet opens a draft timesheet for the week containing the given time.
+func (s *timesheetsSvc) CreateTimesheet(ctx context.Context, user_id int32, week time.Time) (int32, error) {
+	_, err := s.repo.GetPersonByID(ctx, user_id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoResult
+		}
+		return 0, err
+	}
+
+	id, err := s.repo.CreateTimesheet(ctx, repo.CreateTimesheetParams{
+		UserID:    user_id,
+		WeekStart: truncatePeriod(week, PeriodWeek),
+		CreatedAt: time.Now(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrTimesheetExists
+	}
+	return id, err
+}
+
+// GetTimesheet returns a timesheet with the person's tasks of the week, their time clipped
+// to the week and running sessions counted up to now.
+func (s *timesheetsSvc) GetTimesheet(ctx context.Context, id int32) (Timesheet, error) {
+	timesheet, err := s.getTimesheet(ctx, id)
+	if err != nil {
+		return Timesheet{}, err
+	}
+
+	result := toTimesheet(timesheet)
+	tasks, err := s.repo.GetClippedTasksByUserID(ctx, repo.GetClippedTasksByUserIDParams{
+		Now:    time.Now(),
+		ToDt:   result.WeekEnd,
+		FromDt: result.WeekStart,
+		UserID: timesheet.UserID,
+	})
+	if err != nil {
+		return Timesheet{}, err
+	}
+
+	result.Tasks = []Task{}
+	for _, task := range tasks {
+		t := Task{
+			ID:          task.ID,
+			UserID:      task.UserID,
+			Description: task.Description,
+			StartDt:     task.StartDt.Time,
+			EndDt:       task.EndDt.Time,
+			CreatedAt:   task.CreatedAt,
+			Status:      string(task.Status),
+			Billable:    task.Billable,
+		}
+		t.setDuration(task.TotalSeconds)
+		result.Tasks = append(result.Tasks, t)
+		result.TotalSeconds += task.TotalSeconds
+	}
+	result.Duration = isoDuration(result.TotalSeconds)
+	return result, nil
+}
+
+// ListTimesheets returns the timesheets matching the filter, latest week first; zero values don't filter.
+func (s *timesheetsSvc) ListTimesheets(ctx context.Context, user_id int32, status string) ([]Timesheet, error) {
+	arg := repo.ListTimesheetsParams{
+		UserID: user_id,
+	}
+	if status != "" {
+		st := repo.TimesheetStatus(status)
+		if !validTimesheetStatus(st) {
+			return nil, ErrInvalidTimesheetStatus
+		}
+		arg.Status = repo.NullTimesheetStatus{
+			TimesheetStatus: st,
+			Valid:           true,
+		}
+	}
+
+	timesheets, err := s.repo.ListTimesheets(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Timesheet{}
+	for _, timesheet := range timesheets {
+		result = append(result, toTimesheet(timesheet))
+	}
+	return result, nil
+}
+
+// SubmitTimesheet hands a draft or rejected timesheet over for review. The comment of
+// a previous rejection is cleared.
+func (s *timesheetsSvc) SubmitTimesheet(ctx context.Context, id int32) error {
+	timesheet, err := s.getTimesheet(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.setStatus(ctx, timesheet, repo.SetTimesheetStatusParams{
+		Status:      repo.TimesheetStatusSubmitted,
+		SubmittedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+// ApproveTimesheet accepts a submitted timesheet, locking the time tracked within its week.
+func (s *timesheetsSvc) ApproveTimesheet(ctx context.Context, id int32, reviewedBy, comment string) error {
+	timesheet, err := s.getTimesheet(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.setStatus(ctx, timesheet, repo.SetTimesheetStatusParams{
+		Status:      repo.TimesheetStatusApproved,
+		Comment:     comment,
+		SubmittedAt: timesheet.SubmittedAt,
+		ReviewedBy:  reviewedBy,
+		ReviewedAt:  sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+// RejectTimesheet sends a submitted timesheet back to the person; the comment says why.
+func (s *timesheetsSvc) RejectTimesheet(ctx context.Context, id int32, reviewedBy, comment string) error {
+	if comment == "" {
+		return ErrEmptyComment
+	}
+	timesheet, err := s.getTimesheet(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.setStatus(ctx, timesheet, repo.SetTimesheetStatusParams{
+		Status:      repo.TimesheetStatusRejected,
+		Comment:     comment,
+		SubmittedAt: timesheet.SubmittedAt,
+		ReviewedBy:  reviewedBy,
+		ReviewedAt:  sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+func (s *timesheetsSvc) getTimesheet(ctx context.Context, id int32) (repo.Timesheet, error) {
+	timesheet, err := s.repo.GetTimesheetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.Timesheet{}, ErrNoResult
+		}
+		return repo.Timesheet{}, err
+	}
+	return timesheet, nil
+}
+
+// setStatus moves the timesheet to arg.Status. The update only applies while the timesheet
+// is still in the status it was read in, so concurrent reviews can't both succeed.
+func (s *timesheetsSvc) setStatus(ctx context.Context, timesheet repo.Timesheet, arg repo.SetTimesheetStatusParams) error {
+	if !timesheetTransitionAllowed(timesheet.Status, arg.Status) {
+		return ErrInvalidTimesheetTransition
+	}
+
+	arg.ID = timesheet.ID
+	arg.FromStatus = timesheet.Status
+	n, err := s.repo.SetTimesheetStatus(ctx, arg)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInvalidTimesheetTransition
+	}
+	return nil
+}
+
+// timesheetTransitions lists the statuses a timesheet may move to from each status.
+// approved is final.
+var timesheetTransitions = map[repo.TimesheetStatus][]repo.TimesheetStatus{
+	repo.TimesheetStatusDraft:     {repo.TimesheetStatusSubmitted},
+	repo.TimesheetStatusSubmitted: {repo.TimesheetStatusApproved, repo.TimesheetStatusRejected},
+	repo.TimesheetStatusRejected:  {repo.TimesheetStatusSubmitted},
+}
+
+func timesheetTransitionAllowed(from, to repo.TimesheetStatus) bool {
+	for _, allowed := range timesheetTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func validTimesheetStatus(status repo.TimesheetStatus) bool {
+	switch status {
+	case repo.TimesheetStatusDraft, repo.TimesheetStatusSubmitted, repo.TimesheetStatusApproved, repo.TimesheetStatusRejected:
+		return true
+	}
+	return false
+}
+
+func toTimesheet(timesheet repo.Timesheet) Timesheet {
+	return Timesheet{
+		ID:          timesheet.ID,
+		UserID:      timesheet.UserID,
+		WeekStart:   timesheet.WeekStart,
+		WeekEnd:     nextPeriod(timesheet.WeekStart, PeriodWeek),
+		Status:      string(timesheet.Status),
+		Comment:     timesheet.Comment,
+		SubmittedAt: timesheet.SubmittedAt.Time,
+		ReviewedBy:  timesheet.ReviewedBy,
+		ReviewedAt:  timesheet.ReviewedAt.Time,
+		CreatedAt:   timesheet.CreatedAt,
+	}
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "time_entries" (
+  "id" serial PRIMARY KEY,
+  "task_id" int NOT NULL,
+  "start_dt" timestamp NOT NULL,
+  "end_dt" timestamp
+);
+
+ALTER TABLE "time_entries" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id");
+
+CREATE INDEX ON "time_entries" ("task_id");
+
+INSERT INTO "time_entries" ("task_id", "start_dt", "end_dt")
+SELECT "id", "start_dt", "end_dt" FROM "tasks" WHERE "start_dt" IS NOT NULL;
@@ -0,0 +1,19 @@
+CREATE TYPE "task_status" AS ENUM (
+  'created',
+  'running',
+  'paused',
+  'done',
+  'cancelled'
+);
+
+ALTER TABLE "tasks" ADD COLUMN "status" task_status NOT NULL DEFAULT 'created';
+
+UPDATE "tasks" SET "status" = 'done' WHERE "end_dt" IS NOT NULL;
+
+UPDATE "tasks" SET "status" = 'running'
+WHERE "end_dt" IS NULL AND EXISTS (
+  SELECT 1 FROM "time_entries" te WHERE te."task_id" = "tasks"."id" AND te."end_dt" IS NULL
+);
+
+UPDATE "tasks" SET "status" = 'paused'
+WHERE "end_dt" IS NULL AND "start_dt" IS NOT NULL AND "status" = 'created';
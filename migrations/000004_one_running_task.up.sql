@@ -0,0 +1,17 @@
+UPDATE "time_entries" SET "end_dt" = now()
+WHERE "end_dt" IS NULL AND "task_id" IN (
+  SELECT "id" FROM (
+    SELECT "id", row_number() OVER (PARTITION BY "user_id" ORDER BY "start_dt" DESC) AS "rn"
+    FROM "tasks" WHERE "status" = 'running'
+  ) r WHERE r."rn" > 1
+);
+
+UPDATE "tasks" SET "status" = 'paused'
+WHERE "id" IN (
+  SELECT "id" FROM (
+    SELECT "id", row_number() OVER (PARTITION BY "user_id" ORDER BY "start_dt" DESC) AS "rn"
+    FROM "tasks" WHERE "status" = 'running'
+  ) r WHERE r."rn" > 1
+);
+
+CREATE UNIQUE INDEX "tasks_one_running_per_user" ON "tasks" ("user_id") WHERE "status" = 'running';
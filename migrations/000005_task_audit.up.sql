@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "task_audit" (
+  "id" serial PRIMARY KEY,
+  "task_id" int NOT NULL,
+  "changed_by" varchar NOT NULL,
+  "changed_at" timestamp NOT NULL,
+  "note" varchar NOT NULL
+);
+
+ALTER TABLE "task_audit" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id");
+
+CREATE INDEX ON "task_audit" ("task_id");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tasks_description_fts;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE "tasks" ADD COLUMN "deleted_at" timestamp;
+
+CREATE INDEX "tasks_description_fts" ON "tasks" USING gin (to_tsvector('simple', "description"));
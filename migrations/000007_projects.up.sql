@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "clients" (
+  "id" serial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "address" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "projects" (
+  "id" serial PRIMARY KEY,
+  "client_id" int,
+  "name" varchar NOT NULL,
+  "created_at" timestamp NOT NULL
+);
+
+ALTER TABLE "projects" ADD FOREIGN KEY ("client_id") REFERENCES "clients" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "projects" ("client_id");
+
+ALTER TABLE "tasks" ADD COLUMN "project_id" int;
+
+ALTER TABLE "tasks" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "tasks" ("project_id");
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "tags" (
+  "id" serial PRIMARY KEY,
+  "name" varchar NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS "task_tags" (
+  "task_id" int NOT NULL,
+  "tag_id" int NOT NULL,
+  PRIMARY KEY ("task_id", "tag_id")
+);
+
+ALTER TABLE "task_tags" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "task_tags" ADD FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "task_tags" ("tag_id");
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "rates" (
+  "id" serial PRIMARY KEY,
+  "user_id" int,
+  "project_id" int,
+  "task_id" int,
+  "amount" numeric(12, 2) NOT NULL CHECK ("amount" >= 0),
+  "effective_from" timestamp NOT NULL,
+  "created_at" timestamp NOT NULL,
+  CHECK (num_nonnulls("user_id", "project_id", "task_id") = 1)
+);
+
+ALTER TABLE "rates" ADD FOREIGN KEY ("user_id") REFERENCES "people" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "rates" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "rates" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "rates" ("user_id", "effective_from");
+
+CREATE INDEX ON "rates" ("project_id", "effective_from");
+
+CREATE INDEX ON "rates" ("task_id", "effective_from");
+
+ALTER TABLE "tasks" ADD COLUMN "billable" boolean NOT NULL DEFAULT true;
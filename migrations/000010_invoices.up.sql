@@ -0,0 +1,36 @@
+CREATE SEQUENCE IF NOT EXISTS "invoice_number_seq";
+
+CREATE TABLE IF NOT EXISTS "invoices" (
+  "id" serial PRIMARY KEY,
+  "number" varchar NOT NULL UNIQUE,
+  "client_id" int,
+  "client_name" varchar NOT NULL,
+  "client_address" varchar NOT NULL,
+  "from_dt" timestamp NOT NULL,
+  "to_dt" timestamp NOT NULL,
+  "tax_rate" numeric(5, 2) NOT NULL,
+  "subtotal" numeric(14, 2) NOT NULL,
+  "tax" numeric(14, 2) NOT NULL,
+  "total" numeric(14, 2) NOT NULL,
+  "created_at" timestamp NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "invoice_lines" (
+  "id" serial PRIMARY KEY,
+  "invoice_id" int NOT NULL,
+  "project_id" int NOT NULL,
+  "project_name" varchar NOT NULL,
+  "user_id" int NOT NULL,
+  "person_name" varchar NOT NULL,
+  "rate" numeric(12, 2) NOT NULL,
+  "seconds" bigint NOT NULL,
+  "amount" numeric(14, 2) NOT NULL
+);
+
+ALTER TABLE "invoices" ADD FOREIGN KEY ("client_id") REFERENCES "clients" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "invoice_lines" ADD FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "invoices" ("client_id");
+
+CREATE INDEX ON "invoice_lines" ("invoice_id");
@@ -0,0 +1,2 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS budget_seconds;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_seconds;
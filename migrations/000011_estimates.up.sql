@@ -0,0 +1,3 @@
+ALTER TABLE "tasks" ADD COLUMN "estimate_seconds" bigint CHECK ("estimate_seconds" >= 0);
+
+ALTER TABLE "projects" ADD COLUMN "budget_seconds" bigint CHECK ("budget_seconds" >= 0);
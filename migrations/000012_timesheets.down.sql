@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS timesheets;
+DROP TYPE IF EXISTS timesheet_status;
@@ -0,0 +1,23 @@
+CREATE TYPE "timesheet_status" AS ENUM (
+  'draft',
+  'submitted',
+  'approved',
+  'rejected'
+);
+
+CREATE TABLE IF NOT EXISTS "timesheets" (
+  "id" serial PRIMARY KEY,
+  "user_id" int NOT NULL,
+  "week_start" timestamp NOT NULL,
+  "status" timesheet_status NOT NULL DEFAULT 'draft',
+  "comment" varchar NOT NULL DEFAULT '',
+  "submitted_at" timestamp,
+  "reviewed_by" varchar NOT NULL DEFAULT '',
+  "reviewed_at" timestamp,
+  "created_at" timestamp NOT NULL,
+  UNIQUE ("user_id", "week_start")
+);
+
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("user_id") REFERENCES "people" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "timesheets" ("status", "week_start");
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "period_locks" (
+  "id" serial PRIMARY KEY,
+  "user_id" int,
+  "from_dt" timestamp NOT NULL,
+  "to_dt" timestamp NOT NULL,
+  "locked_by" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL,
+  CHECK ("from_dt" < "to_dt")
+);
+
+ALTER TABLE "period_locks" ADD FOREIGN KEY ("user_id") REFERENCES "people" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "period_locks" ("user_id", "from_dt");
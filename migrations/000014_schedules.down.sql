@@ -0,0 +1,4 @@
+ALTER TABLE people DROP COLUMN IF EXISTS work_end_minute;
+ALTER TABLE people DROP COLUMN IF EXISTS work_start_minute;
+ALTER TABLE people DROP COLUMN IF EXISTS work_days;
+ALTER TABLE people DROP COLUMN IF EXISTS weekly_seconds;
@@ -0,0 +1,9 @@
+ALTER TABLE "people" ADD COLUMN "weekly_seconds" bigint NOT NULL DEFAULT 144000 CHECK ("weekly_seconds" >= 0);
+
+ALTER TABLE "people" ADD COLUMN "work_days" int NOT NULL DEFAULT 31 CHECK ("work_days" BETWEEN 0 AND 127);
+
+ALTER TABLE "people" ADD COLUMN "work_start_minute" int NOT NULL DEFAULT 540 CHECK ("work_start_minute" BETWEEN 0 AND 1440);
+
+ALTER TABLE "people" ADD COLUMN "work_end_minute" int NOT NULL DEFAULT 1080 CHECK ("work_end_minute" BETWEEN 0 AND 1440);
+
+ALTER TABLE "people" ADD CHECK ("work_start_minute" < "work_end_minute");
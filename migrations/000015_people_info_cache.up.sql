@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "people_info_cache" (
+  "passport_serie" int NOT NULL,
+  "passport_number" int NOT NULL,
+  "result" varchar NOT NULL CHECK ("result" IN ('found', 'not_found', 'bad_request')),
+  "surname" varchar NOT NULL DEFAULT '',
+  "name" varchar NOT NULL DEFAULT '',
+  "patronymic" varchar NOT NULL DEFAULT '',
+  "address" varchar NOT NULL DEFAULT '',
+  "expires_at" timestamp NOT NULL,
+  PRIMARY KEY ("passport_serie", "passport_number")
+);
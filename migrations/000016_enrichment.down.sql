@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS enrichment_jobs;
+ALTER TABLE people DROP COLUMN IF EXISTS enrichment_error;
+ALTER TABLE people DROP COLUMN IF EXISTS enrichment_status;
+DROP TYPE IF EXISTS enrichment_status;
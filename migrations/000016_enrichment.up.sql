@@ -0,0 +1,23 @@
+CREATE TYPE "enrichment_status" AS ENUM (
+  'pending',
+  'enriched',
+  'failed'
+);
+
+ALTER TABLE "people" ADD COLUMN "enrichment_status" enrichment_status NOT NULL DEFAULT 'enriched';
+
+ALTER TABLE "people" ADD COLUMN "enrichment_error" varchar NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "enrichment_jobs" (
+  "id" serial PRIMARY KEY,
+  "person_id" int NOT NULL UNIQUE,
+  "attempts" int NOT NULL DEFAULT 0,
+  "run_at" timestamp NOT NULL,
+  "locked_until" timestamp,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL
+);
+
+ALTER TABLE "enrichment_jobs" ADD FOREIGN KEY ("person_id") REFERENCES "people" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "enrichment_jobs" ("run_at");